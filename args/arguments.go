@@ -5,7 +5,9 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
 	"github.com/cryptomkt/cryptomkt-go/v3/internal"
 )
 
@@ -19,6 +21,32 @@ import (
 // in the example above.
 type Argument func(map[string]interface{})
 
+// Number is a decimal number, as a string or a decimal.Decimal
+type Number interface {
+	string | decimal.Decimal
+}
+
+// numberString returns the number as the string sent to the exchange
+func numberString[T Number](val T) string {
+	if number, ok := any(val).(decimal.Decimal); ok {
+		return number.String()
+	}
+	return any(val).(string)
+}
+
+// Time is a time, as an ISO 8601 string or a time.Time
+type Time interface {
+	string | time.Time
+}
+
+// timeString returns the time as the string sent to the exchange
+func timeString[T Time](val T) string {
+	if t, ok := any(val).(time.Time); ok {
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return any(val).(string)
+}
+
 func fromSnakeCaseToCamelCase(s string) string {
 	snakeParts := strings.Split(s, "_")
 	camelParts := make([]string, 0)
@@ -144,9 +172,10 @@ func OrderBy(val OrderByType) Argument {
 	}
 }
 
-func From(val string) Argument {
+// From accepts an ISO 8601 string or a time.Time
+func From[T Time](val T) Argument {
 	return func(params map[string]interface{}) {
-		params[internal.ArgNameFrom] = val
+		params[internal.ArgNameFrom] = timeString(val)
 	}
 }
 
@@ -156,9 +185,10 @@ func To(val string) Argument {
 	}
 }
 
-func Till(val string) Argument {
+// Till accepts an ISO 8601 string or a time.Time
+func Till[T Time](val T) Argument {
 	return func(params map[string]interface{}) {
-		params[internal.ArgNameTill] = val
+		params[internal.ArgNameTill] = timeString(val)
 	}
 }
 
@@ -198,21 +228,31 @@ func Side(val SideType) Argument {
 	}
 }
 
-func Quantity(val string) Argument {
+// Quantity accepts a string or a decimal.Decimal
+func Quantity[T Number](val T) Argument {
+	return func(params map[string]interface{}) {
+		params[internal.ArgNameQuantity] = numberString(val)
+	}
+}
+
+// Price accepts a string or a decimal.Decimal
+func Price[T Number](val T) Argument {
 	return func(params map[string]interface{}) {
-		params[internal.ArgNameQuantity] = val
+		params[internal.ArgNamePrice] = numberString(val)
 	}
 }
 
-func Price(val string) Argument {
+// StopPrice accepts a string or a decimal.Decimal
+func StopPrice[T Number](val T) Argument {
 	return func(params map[string]interface{}) {
-		params[internal.ArgNamePrice] = val
+		params[internal.ArgNameStopPrice] = numberString(val)
 	}
 }
 
-func StopPrice(val string) Argument {
+// Type is the type of an order
+func Type(val OrderType) Argument {
 	return func(params map[string]interface{}) {
-		params[internal.ArgNameStopPrice] = val
+		params[internal.ArgNameOrderType] = val
 	}
 }
 
@@ -222,9 +262,10 @@ func TimeInForce(val TimeInForceType) Argument {
 	}
 }
 
-func ExpireTime(val string) Argument {
+// ExpireTime accepts an ISO 8601 string or a time.Time
+func ExpireTime[T Time](val T) Argument {
 	return func(params map[string]interface{}) {
-		params[internal.ArgNameExpireTime] = val
+		params[internal.ArgNameExpireTime] = timeString(val)
 	}
 }
 
@@ -246,9 +287,10 @@ func OrderID(val int) Argument {
 	}
 }
 
-func Amount(val string) Argument {
+// Amount accepts a string or a decimal.Decimal
+func Amount[T Number](val T) Argument {
 	return func(params map[string]interface{}) {
-		params[internal.ArgNameAmount] = val
+		params[internal.ArgNameAmount] = numberString(val)
 	}
 }
 
@@ -336,9 +378,10 @@ func Destination(val AccountType) Argument {
 	}
 }
 
-func Since(val string) Argument {
+// Since accepts an ISO 8601 string or a time.Time
+func Since[T Time](val T) Argument {
 	return func(params map[string]interface{}) {
-		params[internal.ArgNameSince] = val
+		params[internal.ArgNameSince] = timeString(val)
 	}
 }
 
@@ -446,6 +489,33 @@ type OrderRequest struct {
 	TakeRate       string          `json:"take_rate,omitempty"`
 }
 
+// OrderRequestOf returns the order of the params of a new order, as built by BuildParams
+func OrderRequestOf(params map[string]interface{}) OrderRequest {
+	stringOf := func(name string) string {
+		if value, ok := params[name]; ok {
+			return fmt.Sprint(value)
+		}
+		return ""
+	}
+	strictValidate, _ := params[internal.ArgNameStrictValidate].(bool)
+	postOnly, _ := params[internal.ArgNamePostOnly].(bool)
+	return OrderRequest{
+		ClientOrderID:  stringOf(internal.ArgNameClientOrderID),
+		Symbol:         stringOf(internal.ArgNameSymbol),
+		Side:           SideType(stringOf(internal.ArgNameSide)),
+		Type:           OrderType(stringOf(internal.ArgNameOrderType)),
+		TimeInForce:    TimeInForceType(stringOf(internal.ArgNameTimeInForce)),
+		Quantity:       stringOf(internal.ArgNameQuantity),
+		Price:          stringOf(internal.ArgNamePrice),
+		StopPrice:      stringOf(internal.ArgNameStopPrice),
+		ExpireTime:     stringOf(internal.ArgNameExpireTime),
+		StrictValidate: strictValidate,
+		PostOnly:       postOnly,
+		MakeRate:       stringOf(internal.ArgNameMakeRate),
+		TakeRate:       stringOf(internal.ArgNameTakeRate),
+	}
+}
+
 func Orders(val []OrderRequest) Argument {
 	return func(params map[string]interface{}) {
 		params[internal.ArgNameOrders] = val
@@ -169,6 +169,8 @@ const (
 	NotificationSnapshot NotificationType = "snapshot"
 	NotificationUpdate   NotificationType = "update"
 	NotificationData     NotificationType = "data"
+	// NotificationResynced is sent by the websocket clients after a reconnection, the next notification is a fresh snapshot
+	NotificationResynced NotificationType = "resynced"
 )
 
 type ContingencyType string
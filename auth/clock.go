@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Clock keeps the offset between the local clock and the clock of the exchange,
+// calibrated from the server times seen in responses, so request timestamps stay
+// inside the window on hosts with drifting clocks.
+//
+// One Clock can be shared by the rest and websocket clients. Safe for concurrent use.
+// A nil *Clock is the uncalibrated local clock.
+type Clock struct {
+	lock       *sync.RWMutex
+	offset     time.Duration
+	calibrated bool
+	now        func() time.Time
+}
+
+// NewClock creates a clock without offset
+func NewClock() *Clock {
+	return &Clock{
+		lock: new(sync.RWMutex),
+		now:  time.Now,
+	}
+}
+
+// Now returns the estimated time of the exchange
+func (clock *Clock) Now() time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+	clock.lock.RLock()
+	defer clock.lock.RUnlock()
+	return clock.now().Add(clock.offset)
+}
+
+// UnixMilli returns the estimated time of the exchange in milliseconds since the epoch, as used to sign requests
+func (clock *Clock) UnixMilli() int64 {
+	return clock.Now().UnixMilli()
+}
+
+// Skew returns the measured offset of the exchange clock from the local clock.
+// Positive if the local clock is behind
+func (clock *Clock) Skew() time.Duration {
+	if clock == nil {
+		return 0
+	}
+	clock.lock.RLock()
+	defer clock.lock.RUnlock()
+	return clock.offset
+}
+
+// Calibrated reports whether the clock has seen any server time
+func (clock *Clock) Calibrated() bool {
+	if clock == nil {
+		return false
+	}
+	clock.lock.RLock()
+	defer clock.lock.RUnlock()
+	return clock.calibrated
+}
+
+// Observe calibrates the clock with a server time truncated to the given precision,
+// seen in a response to a request sent and received at the given local times.
+//
+// The observation bounds the offset. The current offset is kept if it is inside the bounds,
+// otherwise it moves to their middle, so coarse observations do not undo fine ones.
+func (clock *Clock) Observe(serverTime time.Time, precision time.Duration, sent, received time.Time) {
+	if clock == nil || serverTime.IsZero() || received.Before(sent) {
+		return
+	}
+	lower := serverTime.Sub(received)
+	upper := serverTime.Add(precision).Sub(sent)
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+	if clock.calibrated && lower <= clock.offset && clock.offset <= upper {
+		return
+	}
+	clock.offset = lower + (upper-lower)/2
+	clock.calibrated = true
+}
+
+// ObserveHeader calibrates the clock with the Date header of a response, if present
+func (clock *Clock) ObserveHeader(header http.Header, sent, received time.Time) {
+	if clock == nil {
+		return
+	}
+	date, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		return
+	}
+	clock.Observe(date, time.Second, sent, received)
+}
+
+// ObserveTimestamp calibrates the clock with an ISO 8601 timestamp of the exchange, as the ones of error responses
+func (clock *Clock) ObserveTimestamp(timestamp string, sent, received time.Time) {
+	if clock == nil {
+		return
+	}
+	serverTime, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return
+	}
+	clock.Observe(serverTime, time.Millisecond, sent, received)
+}
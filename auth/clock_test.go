@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClockObserve(t *testing.T) {
+	clock := NewClock()
+	local := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock.now = func() time.Time { return local }
+	server := local.Add(5 * time.Second)
+	clock.Observe(server, time.Millisecond, local.Add(-10*time.Millisecond), local.Add(10*time.Millisecond))
+	if skew := clock.Skew(); skew < 4990*time.Millisecond || skew > 5010*time.Millisecond {
+		t.Fatalf("expected a skew of about 5s, got %v", skew)
+	}
+	if !clock.Now().Equal(local.Add(clock.Skew())) {
+		t.Fatal("now should apply the skew")
+	}
+	fine := clock.Skew()
+	header := http.Header{"Date": []string{server.Format(http.TimeFormat)}}
+	clock.ObserveHeader(header, local.Add(-10*time.Millisecond), local.Add(10*time.Millisecond))
+	if clock.Skew() != fine {
+		t.Fatalf("a coarse observation inside the bounds should keep the skew, got %v", clock.Skew())
+	}
+	clock.ObserveTimestamp(local.Add(-2*time.Second).Format(time.RFC3339Nano), local, local)
+	if skew := clock.Skew(); skew < -2*time.Second || skew > -2*time.Second+time.Millisecond {
+		t.Fatalf("expected a skew of -2s, got %v", skew)
+	}
+}
+
+func TestNilClock(t *testing.T) {
+	var clock *Clock
+	clock.Observe(time.Now(), time.Second, time.Now(), time.Now())
+	if clock.Skew() != 0 || clock.Calibrated() {
+		t.Fatal("nil clock should not be calibrated")
+	}
+	if time.Since(clock.Now()) > time.Second {
+		t.Fatal("nil clock should be the local clock")
+	}
+}
@@ -0,0 +1,78 @@
+// Package auth has the signing of requests for the HS256 authentication scheme of the exchange,
+// shared by the rest and websocket clients.
+//
+// The Signer interface lets the api secret live outside of the process, as in a remote signing
+// service or an os keyring. HMACSigner is the default implementation, holding the secret in memory.
+// Clock corrects the timestamps of the signatures on hosts with drifting clocks.
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+)
+
+// Payload is the data signed to authenticate a request
+type Payload struct {
+	// Method is the http method of a rest request. Empty for websocket logins
+	Method string
+	// Path is the url path of a rest request, as "/api/3/spot/order". Empty for websocket logins
+	Path string
+	// Body is the url encoded query of a GET request, or the body of other requests. Empty for websocket logins
+	Body string
+	// Timestamp is the time of the request in milliseconds since the epoch
+	Timestamp int64
+	// Window is the window of the request in milliseconds. 0 for the default window
+	Window int
+}
+
+// Message returns the message to sign as specified by the exchange
+//
+// https://api.exchange.cryptomkt.com/#hs256
+func (payload Payload) Message() string {
+	message := payload.Method + payload.Path
+	if len(payload.Body) != 0 {
+		if payload.Method == http.MethodGet {
+			message += "?"
+		}
+		message += payload.Body
+	}
+	message += strconv.FormatInt(payload.Timestamp, 10)
+	if payload.Window != 0 {
+		message += strconv.Itoa(payload.Window)
+	}
+	return message
+}
+
+// Signer signs the payload of requests, returning the signature as a hex string
+type Signer interface {
+	Sign(ctx context.Context, payload Payload) (string, error)
+}
+
+// SignerFunc adapts a function to the Signer interface
+type SignerFunc func(ctx context.Context, payload Payload) (string, error)
+
+// Sign calls the function
+func (fn SignerFunc) Sign(ctx context.Context, payload Payload) (string, error) {
+	return fn(ctx, payload)
+}
+
+// HMACSigner signs with HMAC SHA256 using the api secret
+type HMACSigner struct {
+	secret []byte
+}
+
+// NewHMACSigner creates a signer holding the api secret in memory
+func NewHMACSigner(apiSecret string) *HMACSigner {
+	return &HMACSigner{secret: []byte(apiSecret)}
+}
+
+// Sign returns the hex encoded HMAC SHA256 of the payload message
+func (signer *HMACSigner) Sign(ctx context.Context, payload Payload) (string, error) {
+	hash := hmac.New(sha256.New, signer.secret)
+	hash.Write([]byte(payload.Message()))
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
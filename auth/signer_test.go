@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestPayloadMessage(t *testing.T) {
+	cases := []struct {
+		payload  Payload
+		expected string
+	}{
+		{Payload{Method: "GET", Path: "/api/3/spot/balance", Timestamp: 1}, "GET/api/3/spot/balance1"},
+		{Payload{Method: "GET", Path: "/api/3/spot/order", Body: "symbol=EOSETH", Timestamp: 1}, "GET/api/3/spot/order?symbol=EOSETH1"},
+		{Payload{Method: "POST", Path: "/api/3/spot/order", Body: "side=buy", Timestamp: 1, Window: 10000}, "POST/api/3/spot/orderside=buy110000"},
+		{Payload{Timestamp: 1, Window: 10000}, "110000"},
+	}
+	for _, testCase := range cases {
+		if message := testCase.payload.Message(); message != testCase.expected {
+			t.Errorf("expected %q, got %q", testCase.expected, message)
+		}
+	}
+}
+
+func TestHMACSigner(t *testing.T) {
+	payload := Payload{Method: "GET", Path: "/api/3/spot/balance", Timestamp: 1}
+	signature, err := NewHMACSigner("secret").Sign(context.Background(), payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := hmac.New(sha256.New, []byte("secret"))
+	hash.Write([]byte("GET/api/3/spot/balance1"))
+	if expected := hex.EncodeToString(hash.Sum(nil)); signature != expected {
+		t.Fatalf("expected %s, got %s", expected, signature)
+	}
+}
@@ -0,0 +1,360 @@
+// Package backfill fetches the candles or public trades of a time range too large for a request.
+// The range is split in windows of a request each, fetched concurrently, and merged in ascending order:
+//
+//	client := rest.NewClient("", "", 0)
+//	series, err := backfill.Candles(ctx, client, []string{"EOSETH"}, args.Period1Minute, from, till)
+//	for _, gap := range series[0].Gaps {
+//		fmt.Println("no candles from", gap.From, "till", gap.Till)
+//	}
+//
+// A Checkpoint saves the windows already fetched, so an interrupted backfill resumes from where it stopped.
+// Windows still open, ending after the start of the backfill or in the current candle period, are not saved.
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+const (
+	pageLimit = 1000
+	maxOffset = 100_000
+)
+
+// CandleSource is the source of candles, as a rest.Client
+type CandleSource interface {
+	GetCandlesOfSymbol(ctx context.Context, arguments ...args.Argument) ([]models.Candle, error)
+}
+
+// TradeSource is the source of public trades, as a rest.Client
+type TradeSource interface {
+	GetTradesOfSymbol(ctx context.Context, arguments ...args.Argument) ([]models.PublicTrade, error)
+}
+
+// Range is a time range, From inclusive and Till exclusive
+type Range struct {
+	From time.Time
+	Till time.Time
+}
+
+// Series are the records of a symbol in ascending order, without duplicates
+type Series[T any] struct {
+	Symbol  string
+	Records []T
+	// Gaps are the ranges of more than a period without candles. Always empty for trades
+	Gaps []Range
+}
+
+type config struct {
+	concurrency int
+	checkpoint  *Checkpoint
+	tradeWindow time.Duration
+	now         func() time.Time
+}
+
+// Option is an option of a backfill
+type Option func(*config)
+
+// WithConcurrency sets the max number of windows fetched at the same time. Default is 4
+func WithConcurrency(concurrency int) Option {
+	return func(config *config) {
+		config.concurrency = concurrency
+	}
+}
+
+// WithCheckpoint saves the fetched windows in the checkpoint, and skips the windows already saved in it.
+// Only windows ending before the start of the backfill, and before the current period for candles, are saved
+func WithCheckpoint(checkpoint *Checkpoint) Option {
+	return func(config *config) {
+		config.checkpoint = checkpoint
+	}
+}
+
+// WithTradeWindow sets the time range of a window of trades. Default is an hour.
+// Windows are fetched in pages, so a window only needs to be small enough for the max offset of 100000 trades
+func WithTradeWindow(window time.Duration) Option {
+	return func(config *config) {
+		config.tradeWindow = window
+	}
+}
+
+func newConfig(options []Option) *config {
+	config := &config{concurrency: 4, tradeWindow: time.Hour, now: time.Now}
+	for _, option := range options {
+		option(config)
+	}
+	if config.concurrency < 1 {
+		config.concurrency = 1
+	}
+	return config
+}
+
+// Candles fetches the candles of the symbols in the range, in windows of 1000 periods.
+// Gaps are reported for each series, as the exchange gives no candles for periods without trades
+func Candles(
+	ctx context.Context,
+	source CandleSource,
+	symbols []string,
+	period args.PeriodType,
+	from, till time.Time,
+	options ...Option,
+) ([]Series[models.Candle], error) {
+	step, err := stepOf(period)
+	if err != nil {
+		return nil, err
+	}
+	config := newConfig(options)
+	windows := split(from, till, func(start time.Time) time.Time { return step(start, pageLimit) })
+	job := job[models.Candle]{
+		kind: "candles/" + string(period),
+		// the candle of the current period still changes
+		settled: step(config.now(), -1),
+		fetch: func(ctx context.Context, symbol string, window Range) ([]models.Candle, error) {
+			// a window has at most a page of candles
+			return fetchWindow(ctx, source.GetCandlesOfSymbol, symbol, window, pageLimit, args.Period(period))
+		},
+		timeOf: func(candle models.Candle) time.Time { return candle.Timestamp.Time },
+		keyOf:  func(candle models.Candle) string { return candle.Timestamp.String() },
+	}
+	series, err := job.run(ctx, config, symbols, windows)
+	if err != nil {
+		return nil, err
+	}
+	for i := range series {
+		series[i].Gaps = gapsOf(series[i].Records, from, till, step)
+	}
+	return series, nil
+}
+
+// Trades fetches the public trades of the symbols in the range, in windows of an hour
+// or the duration given with WithTradeWindow
+func Trades(
+	ctx context.Context,
+	source TradeSource,
+	symbols []string,
+	from, till time.Time,
+	options ...Option,
+) ([]Series[models.PublicTrade], error) {
+	config := newConfig(options)
+	windows := split(from, till, func(start time.Time) time.Time { return start.Add(config.tradeWindow) })
+	job := job[models.PublicTrade]{
+		kind:    "trades",
+		settled: config.now(),
+		fetch: func(ctx context.Context, symbol string, window Range) ([]models.PublicTrade, error) {
+			return fetchWindow(ctx, source.GetTradesOfSymbol, symbol, window, 0)
+		},
+		timeOf: func(trade models.PublicTrade) time.Time { return trade.Timestamp.Time },
+		keyOf:  func(trade models.PublicTrade) string { return fmt.Sprint(trade.ID) },
+	}
+	return job.run(ctx, config, symbols, windows)
+}
+
+// job fetches the windows of the symbols
+type job[T any] struct {
+	kind string
+	// settled is the time before which the records no longer change, so windows ending before it are checkpointed
+	settled time.Time
+	fetch   func(ctx context.Context, symbol string, window Range) ([]T, error)
+	timeOf  func(T) time.Time
+	keyOf   func(T) string
+}
+
+// task is a window of a symbol, by their indexes
+type task struct {
+	symbol int
+	window int
+}
+
+// run fetches the windows of all the symbols with at most config.concurrency at the same time.
+// The first error cancels the windows not yet fetched
+func (job job[T]) run(ctx context.Context, config *config, symbols []string, windows []Range) ([]Series[T], error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	tasks := make(chan task)
+	results := make([][][]T, len(symbols))
+	for i := range results {
+		results[i] = make([][]T, len(windows))
+	}
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	for i := 0; i < config.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range tasks {
+				records, err := job.fetchTask(ctx, config.checkpoint, symbols[task.symbol], windows[task.window])
+				if err != nil {
+					fail(err)
+					continue
+				}
+				results[task.symbol][task.window] = records
+			}
+		}()
+	}
+send:
+	for symbol := range symbols {
+		for window := range windows {
+			select {
+			case tasks <- task{symbol: symbol, window: window}:
+			case <-ctx.Done():
+				break send
+			}
+		}
+	}
+	close(tasks)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	series := make([]Series[T], len(symbols))
+	for i, symbol := range symbols {
+		series[i] = Series[T]{Symbol: symbol, Records: job.merge(results[i])}
+	}
+	return series, nil
+}
+
+func (job job[T]) fetchTask(ctx context.Context, checkpoint *Checkpoint, symbol string, window Range) ([]T, error) {
+	key := checkpointKey(job.kind, symbol, window)
+	var records []T
+	if checkpoint.load(key, &records) {
+		return records, nil
+	}
+	records, err := job.fetch(ctx, symbol, window)
+	if err != nil {
+		return nil, err
+	}
+	// a window still open would be reused partial on resume
+	if !window.Till.Before(job.settled) {
+		return records, nil
+	}
+	if err := checkpoint.save(key, records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// merge joins the records of the windows in ascending order, without the duplicates at the bounds
+func (job job[T]) merge(windows [][]T) []T {
+	var merged []T
+	seen := make(map[string]bool)
+	for _, records := range windows {
+		for _, record := range records {
+			key := job.keyOf(record)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, record)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return job.timeOf(merged[i]).Before(job.timeOf(merged[j]))
+	})
+	return merged
+}
+
+// fetchWindow fetches the records of a window in ascending order, in pages until a page is
+// not full or there are maxRecords. 0 is no max
+func fetchWindow[T any](
+	ctx context.Context,
+	fetch func(ctx context.Context, arguments ...args.Argument) ([]T, error),
+	symbol string,
+	window Range,
+	maxRecords int,
+	arguments ...args.Argument,
+) ([]T, error) {
+	arguments = append(arguments,
+		args.Symbol(symbol),
+		args.Sort(args.SortASC),
+		args.From(window.From),
+		// the till of the exchange is inclusive
+		args.Till(window.Till.Add(-time.Millisecond)),
+		args.Limit(pageLimit),
+	)
+	var records []T
+	for offset := 0; ; offset += pageLimit {
+		if offset > maxOffset {
+			return nil, &models.SDKError{Message: fmt.Sprintf("too many records in the window from %v till %v of %s, use a smaller window", window.From, window.Till, symbol)}
+		}
+		page, err := fetch(ctx, append(arguments, args.Offset(offset))...)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, page...)
+		if len(page) < pageLimit || (maxRecords > 0 && len(records) >= maxRecords) {
+			return records, nil
+		}
+	}
+}
+
+// split splits the range in consecutive windows, each ending at the next of its start
+func split(from, till time.Time, next func(time.Time) time.Time) []Range {
+	var windows []Range
+	for start := from; start.Before(till); {
+		end := next(start)
+		if end.After(till) {
+			end = till
+		}
+		windows = append(windows, Range{From: start, Till: end})
+		start = end
+	}
+	return windows
+}
+
+// gapsOf returns the ranges of more than a period without candles
+func gapsOf(candles []models.Candle, from, till time.Time, step func(time.Time, int) time.Time) []Range {
+	var gaps []Range
+	expected := from
+	for _, candle := range candles {
+		at := candle.Timestamp.Time
+		if at.After(expected) && !at.Before(step(expected, 1)) {
+			gaps = append(gaps, Range{From: expected, Till: at})
+		}
+		expected = step(at, 1)
+	}
+	if expected.Before(till) && !till.Before(step(expected, 1)) {
+		gaps = append(gaps, Range{From: expected, Till: till})
+	}
+	return gaps
+}
+
+// stepOf returns a function that moves a time n periods
+func stepOf(period args.PeriodType) (func(time.Time, int) time.Time, error) {
+	if period == args.Period1Month {
+		return func(at time.Time, n int) time.Time { return at.AddDate(0, n, 0) }, nil
+	}
+	durations := map[args.PeriodType]time.Duration{
+		args.Period1Minute:   time.Minute,
+		args.Period3Minutes:  3 * time.Minute,
+		args.Period5Minutes:  5 * time.Minute,
+		args.Period15Minutes: 15 * time.Minute,
+		args.Period30Minutes: 30 * time.Minute,
+		args.Period1Hour:     time.Hour,
+		args.Period4Hours:    4 * time.Hour,
+		args.Period1Day:      24 * time.Hour,
+		args.Period7Days:     7 * 24 * time.Hour,
+	}
+	duration, ok := durations[period]
+	if !ok {
+		return nil, &models.SDKError{Message: "invalid period " + string(period)}
+	}
+	return func(at time.Time, n int) time.Time { return at.Add(time.Duration(n) * duration) }, nil
+}
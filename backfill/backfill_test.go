@@ -0,0 +1,144 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/cryptomkttest"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+var start = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// serverWithCandles returns a server with a candle per minute of the first 2500 minutes, but the minutes 1200 to 1209
+func serverWithCandles() *cryptomkttest.Server {
+	server := cryptomkttest.NewServer()
+	for i := 0; i < 2500; i++ {
+		if i >= 1200 && i < 1210 {
+			continue
+		}
+		server.AddCandles("BTCUSDT", models.Candle{
+			Timestamp: models.NewTime(start.Add(time.Duration(i) * time.Minute)),
+			Open:      decimal.MustParse("20000"),
+			Close:     decimal.MustParse("20000"),
+		})
+	}
+	return server
+}
+
+// failingSource fails the calls after the first n
+type failingSource struct {
+	CandleSource
+	calls int32
+	n     int32
+}
+
+func (source *failingSource) GetCandlesOfSymbol(ctx context.Context, arguments ...args.Argument) ([]models.Candle, error) {
+	if atomic.AddInt32(&source.calls, 1) > source.n {
+		return nil, errors.New("interrupted")
+	}
+	return source.CandleSource.GetCandlesOfSymbol(ctx, arguments...)
+}
+
+func TestCandles(t *testing.T) {
+	server := serverWithCandles()
+	defer server.Close()
+	series, err := Candles(context.Background(), server.Client(), []string{"BTCUSDT"}, args.Period1Minute,
+		start, start.Add(2500*time.Minute), WithConcurrency(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	candles := series[0].Records
+	if len(candles) != 2490 || !candles[0].Timestamp.Equal(start) {
+		t.Fatalf("unexpected candles, got %d from %s", len(candles), candles[0].Timestamp)
+	}
+	for i := 1; i < len(candles); i++ {
+		if !candles[i].Timestamp.After(candles[i-1].Timestamp.Time) {
+			t.Fatalf("candles out of order at %s", candles[i].Timestamp)
+		}
+	}
+	gaps := series[0].Gaps
+	if len(gaps) != 1 || !gaps[0].From.Equal(start.Add(1200*time.Minute)) || !gaps[0].Till.Equal(start.Add(1210*time.Minute)) {
+		t.Fatalf("unexpected gaps %+v", gaps)
+	}
+}
+
+func TestResumeFromCheckpoint(t *testing.T) {
+	server := serverWithCandles()
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	checkpoint, err := OpenCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	till := start.Add(2500 * time.Minute)
+	source := &failingSource{CandleSource: server.Client(), n: 2}
+	if _, err := Candles(context.Background(), source, []string{"BTCUSDT"}, args.Period1Minute, start, till,
+		WithConcurrency(1), WithCheckpoint(checkpoint)); err == nil {
+		t.Fatal("expected an interruption")
+	}
+	checkpoint.Close()
+	checkpoint, err = OpenCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer checkpoint.Close()
+	if windows := checkpoint.Windows(); windows != 2 {
+		t.Fatalf("expected 2 saved windows, got %d", windows)
+	}
+	source = &failingSource{CandleSource: server.Client(), n: 100}
+	series, err := Candles(context.Background(), source, []string{"BTCUSDT"}, args.Period1Minute, start, till,
+		WithCheckpoint(checkpoint))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source.calls != 1 || len(series[0].Records) != 2490 {
+		t.Fatalf("expected a call for the last window, got %d calls and %d candles", source.calls, len(series[0].Records))
+	}
+}
+
+// withNow sets the time of the start of the backfill
+func withNow(now time.Time) Option {
+	return func(config *config) {
+		config.now = func() time.Time { return now }
+	}
+}
+
+func TestCheckpointSkipsOpenWindows(t *testing.T) {
+	server := serverWithCandles()
+	defer server.Close()
+	checkpoint, err := OpenCheckpoint(filepath.Join(t.TempDir(), "checkpoint.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer checkpoint.Close()
+	// the second window ends in the future, and the third after it
+	if _, err := Candles(context.Background(), server.Client(), []string{"BTCUSDT"}, args.Period1Minute,
+		start, start.Add(2500*time.Minute), WithCheckpoint(checkpoint), withNow(start.Add(1500*time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+	if windows := checkpoint.Windows(); windows != 1 {
+		t.Fatalf("expected only the settled window saved, got %d", windows)
+	}
+	// the window ending in the current period is still open
+	if _, err := Candles(context.Background(), server.Client(), []string{"BTCUSDT"}, args.Period1Minute,
+		start, start.Add(2500*time.Minute), WithCheckpoint(checkpoint), withNow(start.Add(2000*time.Minute+30*time.Second))); err != nil {
+		t.Fatal(err)
+	}
+	if windows := checkpoint.Windows(); windows != 1 {
+		t.Fatalf("expected the window of the current period not saved, got %d", windows)
+	}
+}
+
+func TestCloseNilCheckpoint(t *testing.T) {
+	var checkpoint *Checkpoint
+	if err := checkpoint.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
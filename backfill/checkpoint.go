@@ -0,0 +1,115 @@
+package backfill
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// Checkpoint saves the records of the fetched windows in a file, a line of json per window.
+// A backfill with the checkpoint of an interrupted one takes the saved windows from the file,
+// and fetches only the rest. A nil Checkpoint saves nothing
+type Checkpoint struct {
+	mu      sync.Mutex
+	file    *os.File
+	windows map[string]json.RawMessage
+}
+
+type checkpointLine struct {
+	Key     string          `json:"key"`
+	Records json.RawMessage `json:"records"`
+}
+
+// OpenCheckpoint opens the checkpoint of the path, creating the file if it does not exist.
+// A last line cut by an interruption is ignored
+func OpenCheckpoint(path string) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{windows: make(map[string]json.RawMessage)}
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, &models.SDKError{Message: "can't read the checkpoint", Err: err}
+	}
+	valid := 0
+	for {
+		end := bytes.IndexByte(content[valid:], '\n')
+		if end < 0 {
+			break
+		}
+		var line checkpointLine
+		if err := json.Unmarshal(content[valid:valid+end], &line); err != nil {
+			break
+		}
+		checkpoint.windows[line.Key] = line.Records
+		valid += end + 1
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, &models.SDKError{Message: "can't open the checkpoint", Err: err}
+	}
+	// drops the cut line, so the next windows are appended after the valid ones
+	if err := file.Truncate(int64(valid)); err != nil {
+		file.Close()
+		return nil, &models.SDKError{Message: "can't open the checkpoint", Err: err}
+	}
+	if _, err := file.Seek(int64(valid), 0); err != nil {
+		file.Close()
+		return nil, &models.SDKError{Message: "can't open the checkpoint", Err: err}
+	}
+	checkpoint.file = file
+	return checkpoint, nil
+}
+
+// Windows returns the number of windows saved in the checkpoint
+func (checkpoint *Checkpoint) Windows() int {
+	checkpoint.mu.Lock()
+	defer checkpoint.mu.Unlock()
+	return len(checkpoint.windows)
+}
+
+// Close closes the file of the checkpoint. Closing a nil checkpoint does nothing
+func (checkpoint *Checkpoint) Close() error {
+	if checkpoint == nil {
+		return nil
+	}
+	return checkpoint.file.Close()
+}
+
+func checkpointKey(kind, symbol string, window Range) string {
+	return fmt.Sprintf("%s/%s/%d/%d", kind, symbol, window.From.UnixMilli(), window.Till.UnixMilli())
+}
+
+func (checkpoint *Checkpoint) load(key string, records interface{}) bool {
+	if checkpoint == nil {
+		return false
+	}
+	checkpoint.mu.Lock()
+	raw, ok := checkpoint.windows[key]
+	checkpoint.mu.Unlock()
+	return ok && json.Unmarshal(raw, records) == nil
+}
+
+func (checkpoint *Checkpoint) save(key string, records interface{}) error {
+	if checkpoint == nil {
+		return nil
+	}
+	raw, err := json.Marshal(records)
+	if err != nil {
+		return &models.SDKError{Message: "can't save the checkpoint", Err: err}
+	}
+	line, err := json.Marshal(checkpointLine{Key: key, Records: raw})
+	if err != nil {
+		return &models.SDKError{Message: "can't save the checkpoint", Err: err}
+	}
+	checkpoint.mu.Lock()
+	defer checkpoint.mu.Unlock()
+	if _, err := checkpoint.file.Write(append(line, '\n')); err != nil {
+		return &models.SDKError{Message: "can't save the checkpoint", Err: err}
+	}
+	checkpoint.windows[key] = raw
+	return nil
+}
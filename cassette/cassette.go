@@ -0,0 +1,135 @@
+// Package cassette records the rest requests and websocket frames exchanged with the exchange,
+// and replays them without network access, for deterministic offline tests.
+//
+// Record once against the exchange:
+//
+//	recorder := cassette.NewRecorder(nil, nil)
+//	client := rest.NewClientWithOptions(rest.WithCredentials(apiKey, apiSecret), rest.WithTransport(recorder))
+//	marketClient, err := websocket.NewMarketDataClient(websocket.WithConnector(recorder))
+//	...
+//	recorder.Save("testdata/orders.json")
+//
+// and replay in tests:
+//
+//	player, err := cassette.LoadPlayer("testdata/orders.json")
+//	client := rest.NewClientWithOptions(rest.WithTransport(player))
+//	marketClient, err := websocket.NewMarketDataClient(websocket.WithConnector(player))
+//
+// Credentials and signatures are redacted before being recorded.
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// Redacted replaces the credentials and signatures in the recordings
+const Redacted = "REDACTED"
+
+// redactedHeaders are the headers replaced by Redacted
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// redactedParams are the params of websocket frames replaced by Redacted
+var redactedParams = []string{"api_key", "signature"}
+
+// Cassette is a recording of rest interactions and websocket connections
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+	Connections  []Connection  `json:"connections"`
+}
+
+// Interaction is a rest request and its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded rest request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded rest response
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// Connection is a recorded websocket connection
+type Connection struct {
+	URL    string  `json:"url"`
+	Frames []Frame `json:"frames"`
+}
+
+// Frame is a text frame sent or received through a websocket connection
+type Frame struct {
+	Sent bool   `json:"sent"`
+	Data string `json:"data"`
+}
+
+// Load reads a cassette from a json file
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &models.SDKError{Message: "unable to read cassette", Err: err}
+	}
+	cassette := &Cassette{}
+	if err = json.Unmarshal(data, cassette); err != nil {
+		return nil, &models.SDKError{Message: "unable to parse cassette", Err: err}
+	}
+	return cassette, nil
+}
+
+// Save writes the cassette to a json file
+func (cassette *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return &models.SDKError{Message: "unable to encode cassette", Err: err}
+	}
+	if err = os.WriteFile(path, data, 0644); err != nil {
+		return &models.SDKError{Message: "unable to write cassette", Err: err}
+	}
+	return nil
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, key := range redactedHeaders {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, Redacted)
+		}
+	}
+	return redacted
+}
+
+// redactFrame replaces the credentials in the params of a websocket frame, as the ones of the login
+func redactFrame(data []byte) string {
+	frame := map[string]json.RawMessage{}
+	if json.Unmarshal(data, &frame) != nil {
+		return string(data)
+	}
+	params := map[string]json.RawMessage{}
+	if json.Unmarshal(frame["params"], &params) != nil {
+		return string(data)
+	}
+	redacted := false
+	for _, key := range redactedParams {
+		if _, ok := params[key]; ok {
+			params[key], _ = json.Marshal(Redacted)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return string(data)
+	}
+	frame["params"], _ = json.Marshal(params)
+	encoded, _ := json.Marshal(frame)
+	return string(encoded)
+}
@@ -0,0 +1,183 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/rest"
+	"github.com/cryptomkt/cryptomkt-go/v3/websocket"
+)
+
+func TestRecordAndReplayRest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"currency":"EOS","available":"1.5"}]`))
+	}))
+	recorder := NewRecorder(nil, nil)
+	client := rest.NewClientWithOptions(rest.WithBaseURL(server.URL), rest.WithCredentials("key", "secret"), rest.WithTransport(recorder))
+	if _, err := client.GetSpotTradingBalances(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "HS256") || !strings.Contains(string(data), Redacted) {
+		t.Fatal("credentials should be redacted")
+	}
+
+	player, err := LoadPlayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = rest.NewClientWithOptions(rest.WithBaseURL(server.URL), rest.WithCredentials("other", "keys"), rest.WithTransport(player))
+	balances, err := client.GetSpotTradingBalances(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 1 || !balances[0].Available.Equal(decimal.MustParse("1.5")) {
+		t.Fatalf("unexpected replayed balances %v", balances)
+	}
+	if _, err := client.GetSpotTradingBalances(context.Background()); err == nil {
+		t.Fatal("should fail without more recorded interactions")
+	}
+}
+
+// newTickerServer answers subscriptions with a ticker notification
+func newTickerServer() *httptest.Server {
+	upgrader := gorilla.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			request := struct {
+				ID int64  `json:"id"`
+				Ch string `json:"ch"`
+			}{}
+			json.Unmarshal(data, &request)
+			response, _ := json.Marshal(map[string]interface{}{
+				"id":     request.ID,
+				"result": map[string]interface{}{"ch": request.Ch, "subscriptions": []string{"ETHBTC"}},
+			})
+			conn.WriteMessage(gorilla.TextMessage, response)
+			conn.WriteMessage(gorilla.TextMessage, []byte(`{"ch":"`+request.Ch+`","data":{"ETHBTC":{"c":"0.05"}}}`))
+		}
+	}))
+}
+
+func subscribeToTicker(t *testing.T, connector websocket.Connector) string {
+	client, err := websocket.NewMarketDataClient(websocket.WithConnector(connector))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	subscription, err := client.SubscribeToTicker(args.TickerSpeed(args.TickerSpeed1s), args.Symbols([]string{"ETHBTC"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case notification := <-subscription.NotificationCh:
+		return notification.Data["ETHBTC"].Last.String()
+	case <-time.After(time.Second):
+		t.Fatal("no notification")
+		return ""
+	}
+}
+
+func TestRecordAndReplayWebsocket(t *testing.T) {
+	server := newTickerServer()
+	dialer := websocket.NewDialerConnector(nil)
+	recorder := NewRecorder(nil, websocket.ConnectorFunc(func(url string) (websocket.Conn, *http.Response, error) {
+		return dialer.Connect("ws" + strings.TrimPrefix(server.URL, "http"))
+	}))
+	if last := subscribeToTicker(t, recorder); last != "0.05" {
+		t.Fatalf("unexpected recorded ticker %s", last)
+	}
+	server.Close()
+
+	player := NewPlayer(recorder.Cassette())
+	if last := subscribeToTicker(t, player); last != "0.05" {
+		t.Fatalf("unexpected replayed ticker %s", last)
+	}
+}
+
+func TestRecordWithHeartbeatAndReadLimit(t *testing.T) {
+	pings := make(chan struct{}, 1)
+	upgrader := gorilla.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(data string) error {
+			select {
+			case pings <- struct{}{}:
+			default:
+			}
+			return conn.WriteControl(gorilla.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+			conn.WriteMessage(gorilla.TextMessage, []byte(`{"ch":"trades","update":{"`+strings.Repeat("A", 1024)+`":[]}}`))
+		}
+	}))
+	defer server.Close()
+	dialer := websocket.NewDialerConnector(nil)
+	recorder := NewRecorder(nil, websocket.ConnectorFunc(func(url string) (websocket.Conn, *http.Response, error) {
+		return dialer.Connect("ws" + strings.TrimPrefix(server.URL, "http"))
+	}))
+	client, err := websocket.NewMarketDataClient(
+		websocket.WithConnector(recorder),
+		websocket.WithHeartbeat(10*time.Millisecond, time.Second),
+		websocket.WithReadLimit(512),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	select {
+	case <-pings:
+	case <-time.After(time.Second):
+		t.Fatal("no ping over the recorded connection")
+	}
+	// the message over the read limit closes the connection
+	client.GetActiveSubscriptions(context.Background(), args.Subscription(args.SubscriptionTypeTrades()))
+	for event := range client.Events() {
+		if event.Type == websocket.EventDisconnected {
+			if !strings.Contains(event.Err.Error(), "read limit") {
+				t.Fatalf("expected the read limit as cause, got %v", event.Err)
+			}
+			return
+		}
+	}
+	t.Fatal("expected a disconnection")
+}
+
+func TestRedactFrame(t *testing.T) {
+	frame := redactFrame([]byte(`{"method":"login","params":{"type":"HS256","api_key":"key","signature":"abc","timestamp":1}}`))
+	if strings.Contains(frame, `"key"`) || strings.Contains(frame, "abc") || !strings.Contains(frame, "HS256") {
+		t.Fatalf("unexpected redacted frame %s", frame)
+	}
+}
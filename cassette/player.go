@@ -0,0 +1,216 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	gorilla "github.com/gorilla/websocket"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/cryptomkt/cryptomkt-go/v3/websocket"
+)
+
+// Player is an http.RoundTripper and a websocket.Connector replaying a cassette
+// without network access. Safe for concurrent use.
+//
+// A request is answered with the first unused interaction of equal method, path,
+// query and body. A sent websocket frame is answered with the frames received after
+// the first unused recorded frame of equal method and params, with the id of the sent frame.
+type Player struct {
+	lock         *sync.Mutex
+	cassette     *Cassette
+	interactions []bool
+	connections  []bool
+}
+
+// NewPlayer creates a player of the cassette
+func NewPlayer(cassette *Cassette) *Player {
+	return &Player{
+		lock:         new(sync.Mutex),
+		cassette:     cassette,
+		interactions: make([]bool, len(cassette.Interactions)),
+		connections:  make([]bool, len(cassette.Connections)),
+	}
+}
+
+// LoadPlayer creates a player of the cassette in the json file
+func LoadPlayer(path string) (*Player, error) {
+	cassette, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewPlayer(cassette), nil
+}
+
+// RoundTrip answers the request with the matching recorded response
+func (player *Player) RoundTrip(request *http.Request) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		if body, err = io.ReadAll(request.Body); err != nil {
+			return nil, err
+		}
+		request.Body.Close()
+	}
+	key := requestKey(request.Method, request.URL, string(body))
+	player.lock.Lock()
+	defer player.lock.Unlock()
+	for i, interaction := range player.cassette.Interactions {
+		if player.interactions[i] {
+			continue
+		}
+		recordedURL, err := url.Parse(interaction.Request.URL)
+		if err != nil || requestKey(interaction.Request.Method, recordedURL, interaction.Request.Body) != key {
+			continue
+		}
+		player.interactions[i] = true
+		return &http.Response{
+			Status:        http.StatusText(interaction.Response.Status),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       request,
+		}, nil
+	}
+	return nil, &models.SDKError{Message: "no recorded interaction for " + request.Method + " " + request.URL.RequestURI()}
+}
+
+func requestKey(method string, requestURL *url.URL, body string) string {
+	return method + " " + requestURL.Path + "?" + requestURL.Query().Encode() + " " + body
+}
+
+// Connect opens a replay of the first unused connection recorded with the url
+func (player *Player) Connect(url string) (websocket.Conn, *http.Response, error) {
+	player.lock.Lock()
+	defer player.lock.Unlock()
+	for i, connection := range player.cassette.Connections {
+		if player.connections[i] || connection.URL != url {
+			continue
+		}
+		player.connections[i] = true
+		return newPlayerConn(connection), nil, nil
+	}
+	return nil, nil, &models.SDKError{Message: "no recorded connection to " + url}
+}
+
+// exchange is a recorded sent frame and the frames received after it
+type exchange struct {
+	method   string
+	params   string
+	id       json.RawMessage
+	received []string
+	used     bool
+}
+
+type playerConn struct {
+	lock      *sync.Mutex
+	exchanges []*exchange
+	queue     chan []byte
+	done      chan struct{}
+	closeOnce *sync.Once
+}
+
+func newPlayerConn(connection Connection) *playerConn {
+	conn := &playerConn{
+		lock:      new(sync.Mutex),
+		queue:     make(chan []byte, len(connection.Frames)),
+		done:      make(chan struct{}),
+		closeOnce: new(sync.Once),
+	}
+	var current *exchange
+	for _, frame := range connection.Frames {
+		if frame.Sent {
+			method, params, id := parseFrame([]byte(frame.Data))
+			current = &exchange{method: method, params: params, id: id}
+			conn.exchanges = append(conn.exchanges, current)
+			continue
+		}
+		if current == nil {
+			conn.queue <- []byte(frame.Data)
+			continue
+		}
+		current.received = append(current.received, frame.Data)
+	}
+	return conn
+}
+
+// parseFrame returns the method and channel, the params without credentials and the id of a sent frame
+func parseFrame(data []byte) (method, params string, id json.RawMessage) {
+	frame := struct {
+		Method  string          `json:"method"`
+		Channel string          `json:"ch"`
+		Params  json.RawMessage `json:"params"`
+		ID      json.RawMessage `json:"id"`
+	}{}
+	json.Unmarshal(data, &frame)
+	if frame.Method == "login" {
+		return frame.Method, "", frame.ID
+	}
+	return frame.Method + " " + frame.Channel, string(frame.Params), frame.ID
+}
+
+func (conn *playerConn) ReadMessage() (int, []byte, error) {
+	select {
+	case data := <-conn.queue:
+		return gorilla.TextMessage, data, nil
+	case <-conn.done:
+		return 0, nil, &gorilla.CloseError{Code: gorilla.CloseNormalClosure}
+	}
+}
+
+func (conn *playerConn) WriteMessage(messageType int, data []byte) error {
+	if messageType == gorilla.CloseMessage {
+		conn.Close()
+		return nil
+	}
+	select {
+	case <-conn.done:
+		return gorilla.ErrCloseSent
+	default:
+	}
+	method, params, id := parseFrame(data)
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+	for _, exchange := range conn.exchanges {
+		if exchange.used || exchange.method != method || exchange.params != params {
+			continue
+		}
+		exchange.used = true
+		for _, received := range exchange.received {
+			conn.queue <- withID([]byte(received), exchange.id, id)
+		}
+		return nil
+	}
+	conn.Close()
+	return &models.SDKError{Message: "no recorded frame for " + string(data)}
+}
+
+func (conn *playerConn) Close() error {
+	conn.closeOnce.Do(func() { close(conn.done) })
+	return nil
+}
+
+// withID replaces the recorded id of a response frame with the id of the replayed request
+func withID(data []byte, recordedID, id json.RawMessage) []byte {
+	if len(recordedID) == 0 || bytes.Equal(recordedID, id) {
+		return data
+	}
+	frame := map[string]json.RawMessage{}
+	if json.Unmarshal(data, &frame) != nil || !bytes.Equal(frame["id"], recordedID) {
+		return data
+	}
+	frame["id"] = id
+	encoded, err := json.Marshal(frame)
+	if err != nil {
+		return data
+	}
+	return encoded
+}
@@ -0,0 +1,175 @@
+package cassette
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/websocket"
+)
+
+// Recorder is an http.RoundTripper and a websocket.Connector recording
+// the requests and frames going through it. Safe for concurrent use.
+type Recorder struct {
+	lock      *sync.Mutex
+	cassette  *Cassette
+	transport http.RoundTripper
+	connector websocket.Connector
+}
+
+// NewRecorder creates a recorder of the requests made with the transport and of
+// the connections opened by the connector. Nil uses the defaults of the clients
+func NewRecorder(transport http.RoundTripper, connector websocket.Connector) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if connector == nil {
+		connector = websocket.NewDialerConnector(nil)
+	}
+	return &Recorder{
+		lock:      new(sync.Mutex),
+		cassette:  &Cassette{},
+		transport: transport,
+		connector: connector,
+	}
+}
+
+// RoundTrip makes the request and records it with its response
+func (recorder *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		if body, err = io.ReadAll(request.Body); err != nil {
+			return nil, err
+		}
+		request.Body.Close()
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	response, err := recorder.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: request.Method,
+			URL:    request.URL.String(),
+			Header: redactHeader(request.Header),
+			Body:   string(body),
+		},
+		Response: Response{
+			Status: response.StatusCode,
+			Header: redactHeader(response.Header),
+			Body:   string(responseBody),
+		},
+	})
+	return response, nil
+}
+
+// Connect opens the connection and records its text frames. The recorded connection
+// supports the heartbeat and the read limit of the clients if the opened one does
+func (recorder *Recorder) Connect(url string) (websocket.Conn, *http.Response, error) {
+	conn, response, err := recorder.connector.Connect(url)
+	if err != nil {
+		return nil, response, err
+	}
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	recorder.cassette.Connections = append(recorder.cassette.Connections, Connection{URL: url})
+	recording := &recordingConn{
+		Conn:     conn,
+		recorder: recorder,
+		index:    len(recorder.cassette.Connections) - 1,
+	}
+	if heartbeat, ok := conn.(websocket.HeartbeatConn); ok {
+		return &heartbeatRecordingConn{recordingConn: recording, heartbeat: heartbeat}, response, nil
+	}
+	return recording, response, nil
+}
+
+// Cassette returns a copy of the recordings so far
+func (recorder *Recorder) Cassette() *Cassette {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	cassette := &Cassette{
+		Interactions: append([]Interaction(nil), recorder.cassette.Interactions...),
+		Connections:  make([]Connection, len(recorder.cassette.Connections)),
+	}
+	for i, connection := range recorder.cassette.Connections {
+		cassette.Connections[i] = Connection{
+			URL:    connection.URL,
+			Frames: append([]Frame(nil), connection.Frames...),
+		}
+	}
+	return cassette
+}
+
+// Save writes the recordings so far to a json file
+func (recorder *Recorder) Save(path string) error {
+	return recorder.Cassette().Save(path)
+}
+
+func (recorder *Recorder) recordFrame(index int, sent bool, data []byte) {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	connection := &recorder.cassette.Connections[index]
+	connection.Frames = append(connection.Frames, Frame{Sent: sent, Data: redactFrame(data)})
+}
+
+type recordingConn struct {
+	websocket.Conn
+	recorder *Recorder
+	index    int
+}
+
+func (conn *recordingConn) ReadMessage() (int, []byte, error) {
+	messageType, data, err := conn.Conn.ReadMessage()
+	if err == nil && messageType == gorilla.TextMessage {
+		conn.recorder.recordFrame(conn.index, false, data)
+	}
+	return messageType, data, err
+}
+
+func (conn *recordingConn) WriteMessage(messageType int, data []byte) error {
+	err := conn.Conn.WriteMessage(messageType, data)
+	if err == nil && messageType == gorilla.TextMessage {
+		conn.recorder.recordFrame(conn.index, true, data)
+	}
+	return err
+}
+
+// SetReadLimit sets the read limit of the recorded connection, if it has one
+func (conn *recordingConn) SetReadLimit(limit int64) {
+	if limited, ok := conn.Conn.(interface{ SetReadLimit(limit int64) }); ok {
+		limited.SetReadLimit(limit)
+	}
+}
+
+// heartbeatRecordingConn records a connection supporting the heartbeat of the clients
+type heartbeatRecordingConn struct {
+	*recordingConn
+	heartbeat websocket.HeartbeatConn
+}
+
+func (conn *heartbeatRecordingConn) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	return conn.heartbeat.WriteControl(messageType, data, deadline)
+}
+
+func (conn *heartbeatRecordingConn) SetReadDeadline(t time.Time) error {
+	return conn.heartbeat.SetReadDeadline(t)
+}
+
+func (conn *heartbeatRecordingConn) SetPongHandler(handler func(appData string) error) {
+	conn.heartbeat.SetPongHandler(handler)
+}
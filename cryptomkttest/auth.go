@@ -0,0 +1,140 @@
+package cryptomkttest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+const defaultWindow = 10000
+
+func errAuthorization(code int, description string) *apiError {
+	message := "Authorization failed"
+	if code == models.ErrorCodeAuthorizationRequired {
+		message = "Authorization is required or has been failed"
+	}
+	return newAPIError(http.StatusUnauthorized, code, message, description)
+}
+
+// hs256 returns the hex encoded HMAC SHA256 of the message with the secret.
+// Built apart from the signers of the sdk, so the fake catches their regressions
+func hs256(secret, message string) string {
+	hash := hmac.New(sha256.New, []byte(secret))
+	hash.Write([]byte(message))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// restMessage returns the HS256 message of a rest request as the exchange builds it: the method,
+// the path, "?" and the query for GET requests or the body for the rest, the timestamp and the window
+//
+// https://api.exchange.cryptomkt.com/#hs256
+func restMessage(request *http.Request, body []byte, timestamp, window string) string {
+	message := request.Method + request.URL.Path
+	if request.Method == http.MethodGet {
+		if request.URL.RawQuery != "" {
+			message += "?" + request.URL.RawQuery
+		}
+	} else {
+		message += string(body)
+	}
+	return message + timestamp + window
+}
+
+// authenticate validates the HS256 authorization header of a request with its raw body
+func (server *Server) authenticate(request *http.Request, body []byte) *apiError {
+	header := request.Header.Get("Authorization")
+	if header == "" {
+		return errAuthorization(models.ErrorCodeAuthorizationRequired, "missing authorization header")
+	}
+	if !strings.HasPrefix(header, "HS256 ") {
+		return errAuthorization(models.ErrorCodeUnsupportedAuthorization, "only HS256 authorization is supported")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(header, "HS256 "))
+	if err != nil {
+		return errAuthorization(models.ErrorCodeAuthorizationFailed, "malformed authorization header")
+	}
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 3 && len(parts) != 4 {
+		return errAuthorization(models.ErrorCodeAuthorizationFailed, "malformed authorization header")
+	}
+	timestamp, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return errAuthorization(models.ErrorCodeAuthorizationFailed, "malformed timestamp")
+	}
+	window, rawWindow := 0, ""
+	if len(parts) == 4 {
+		rawWindow = parts[3]
+		if window, err = strconv.Atoi(rawWindow); err != nil {
+			return errAuthorization(models.ErrorCodeAuthorizationFailed, "malformed window")
+		}
+	}
+	if apiError := server.checkWindow(timestamp, window); apiError != nil {
+		return apiError
+	}
+	server.lock.RLock()
+	secret, ok := server.accounts[parts[0]]
+	server.lock.RUnlock()
+	if !ok {
+		return errAuthorization(models.ErrorCodeAuthorizationFailed, "unknown api key")
+	}
+	expected := hs256(secret, restMessage(request, body, parts[2], rawWindow))
+	if !hmac.Equal([]byte(expected), []byte(parts[1])) {
+		return errAuthorization(models.ErrorCodeAuthorizationFailed, "invalid signature")
+	}
+	return nil
+}
+
+// checkWindow rejects timestamps further than the window from the server clock
+func (server *Server) checkWindow(timestamp int64, window int) *apiError {
+	if window == 0 {
+		window = defaultWindow
+	}
+	distance := time.Duration(server.now().UnixMilli()-timestamp) * time.Millisecond
+	if distance < 0 {
+		distance = -distance
+	}
+	if distance > time.Duration(window)*time.Millisecond {
+		return errAuthorization(models.ErrorCodeAuthorizationFailed, "timestamp is out of the window")
+	}
+	return nil
+}
+
+// login validates the params of a websocket login
+func (server *Server) login(params loginParams) *apiError {
+	if params.Type != "HS256" {
+		return errAuthorization(models.ErrorCodeUnsupportedAuthorization, "only HS256 authorization is supported")
+	}
+	if apiError := server.checkWindow(params.Timestamp, params.Window); apiError != nil {
+		return apiError
+	}
+	server.lock.RLock()
+	secret, ok := server.accounts[params.APIKey]
+	server.lock.RUnlock()
+	if !ok {
+		return errAuthorization(models.ErrorCodeAuthorizationFailed, "unknown api key")
+	}
+	// the message of a login is the timestamp and the window, if any
+	message := strconv.FormatInt(params.Timestamp, 10)
+	if params.Window != 0 {
+		message += strconv.Itoa(params.Window)
+	}
+	if !hmac.Equal([]byte(hs256(secret, message)), []byte(params.Signature)) {
+		return errAuthorization(models.ErrorCodeAuthorizationFailed, "invalid signature")
+	}
+	return nil
+}
+
+type loginParams struct {
+	Type      string `json:"type"`
+	APIKey    string `json:"api_key"`
+	Timestamp int64  `json:"timestamp"`
+	Window    int    `json:"window"`
+	Signature string `json:"signature"`
+}
@@ -0,0 +1,15 @@
+package cryptomkttest
+
+import "github.com/cryptomkt/cryptomkt-go/v3/decimal"
+
+// parseAmount parses a decimal string. ok is false for empty or malformed strings
+func parseAmount(value string) (amount decimal.Decimal, ok bool) {
+	amount, err := decimal.Parse(value)
+	return amount, err == nil
+}
+
+// amountOf parses a decimal string, as zero if malformed
+func amountOf(value string) decimal.Decimal {
+	amount, _ := parseAmount(value)
+	return amount
+}
@@ -0,0 +1,685 @@
+package cryptomkttest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// exchange is the in-memory state of the fake exchange, shared by all the
+// accounts: every api key trades on the same balances.
+type exchange struct {
+	lock           *sync.Mutex
+	now            func() time.Time
+	currencies     map[string]models.Currency
+	symbols        map[string]models.Symbol
+	tickers        map[string]models.Ticker
+	orderBooks     map[string]models.OrderBook
+	publicTrades   map[string][]models.PublicTrade
+	candles        map[string][]models.Candle
+	spotBalances   map[string]*models.Balance
+	walletBalances map[string]*models.Balance
+	activeOrders   []*models.Order
+	orderHistory   []models.Order
+	tradeHistory   []models.Trade
+	transactions   []models.Transaction
+	subAccounts    []*subAccount
+	addresses      map[string]models.CryptoAddress
+	lastID         int64
+	listeners      []func(event)
+}
+
+type subAccount struct {
+	models.SubAccount
+	acl      models.ACLSettings
+	balances models.SubAccountBalances
+}
+
+func newExchange() *exchange {
+	return &exchange{
+		lock:           new(sync.Mutex),
+		now:            time.Now,
+		currencies:     make(map[string]models.Currency),
+		symbols:        make(map[string]models.Symbol),
+		tickers:        make(map[string]models.Ticker),
+		orderBooks:     make(map[string]models.OrderBook),
+		publicTrades:   make(map[string][]models.PublicTrade),
+		candles:        make(map[string][]models.Candle),
+		spotBalances:   make(map[string]*models.Balance),
+		walletBalances: make(map[string]*models.Balance),
+		addresses:      make(map[string]models.CryptoAddress),
+	}
+}
+
+// apiError is an error of the exchange with the http status it is responded with
+type apiError struct {
+	status int
+	models.APIError
+}
+
+func newAPIError(status, code int, message, description string) *apiError {
+	return &apiError{
+		status:   status,
+		APIError: models.APIError{Code: code, Message: message, Description: description},
+	}
+}
+
+func errSymbolNotFound(symbol string) *apiError {
+	return newAPIError(http.StatusBadRequest, models.ErrorCodeSymbolNotFound, "Symbol not found", "no symbol "+symbol)
+}
+
+func errCurrencyNotFound(currency string) *apiError {
+	return newAPIError(http.StatusBadRequest, models.ErrorCodeCurrencyNotFound, "Currency not found", "no currency "+currency)
+}
+
+func errOrderNotFound(clientOrderID string) *apiError {
+	return newAPIError(http.StatusBadRequest, models.ErrorCodeOrderNotFound, "Order not found", "no active order "+clientOrderID)
+}
+
+func errInsufficientFunds() *apiError {
+	return newAPIError(http.StatusBadRequest, models.ErrorCodeInsufficientFunds, "Insufficient funds", "Check that the funds are sufficient, given commissions")
+}
+
+func errValidation(description string) *apiError {
+	return newAPIError(http.StatusBadRequest, 10001, "Validation error", description)
+}
+
+func (exchange *exchange) timestamp() models.Time {
+	return models.NewTime(exchange.now())
+}
+
+func (exchange *exchange) nextID() int64 {
+	exchange.lastID++
+	return exchange.lastID
+}
+
+func balanceOf(balances map[string]*models.Balance, currency string) *models.Balance {
+	balance, ok := balances[currency]
+	if !ok {
+		balance = &models.Balance{Currency: currency}
+		balances[currency] = balance
+	}
+	return balance
+}
+
+func sortedBalances(balances map[string]*models.Balance) []models.Balance {
+	result := make([]models.Balance, 0, len(balances))
+	for _, balance := range balances {
+		result = append(result, *balance)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Currency < result[j].Currency })
+	return result
+}
+
+// orderRequest are the params of a new order
+type orderRequest struct {
+	clientOrderID string
+	symbol        string
+	side          args.SideType
+	orderType     args.OrderType
+	timeInForce   args.TimeInForceType
+	quantity      string
+	price         string
+	stopPrice     string
+	expireTime    string
+	postOnly      bool
+}
+
+func orderRequestOf(params map[string]string) orderRequest {
+	request := orderRequest{
+		clientOrderID: params["client_order_id"],
+		symbol:        params["symbol"],
+		side:          args.SideType(params["side"]),
+		orderType:     args.OrderType(params["type"]),
+		timeInForce:   args.TimeInForceType(params["time_in_force"]),
+		quantity:      params["quantity"],
+		price:         params["price"],
+		stopPrice:     params["stop_price"],
+		expireTime:    params["expire_time"],
+		postOnly:      params["post_only"] == "true",
+	}
+	if request.orderType == "" {
+		request.orderType = args.OrderLimit
+	}
+	if request.timeInForce == "" {
+		request.timeInForce = args.TimeInForceGTC
+	}
+	return request
+}
+
+// createOrder places an order. Limit orders crossing the book and market orders are
+// filled at once at the best price of the book, other limit orders rest in the book.
+func (exchange *exchange) createOrder(request orderRequest) (*models.Order, *apiError) {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	symbol, ok := exchange.symbols[request.symbol]
+	if !ok {
+		return nil, errSymbolNotFound(request.symbol)
+	}
+	if request.side != args.SideBuy && request.side != args.SideSell {
+		return nil, errValidation("side must be buy or sell")
+	}
+	if quantity, ok := parseAmount(request.quantity); !ok || quantity.Sign() <= 0 {
+		return nil, errValidation("quantity must be a positive number")
+	}
+	isLimit := request.orderType == args.OrderLimit || request.orderType == args.OrderStopLimit || request.orderType == args.OrderTakeProfitLimit
+	if price, ok := parseAmount(request.price); isLimit && (!ok || price.Sign() <= 0) {
+		return nil, errValidation("price must be a positive number")
+	}
+	expireTime, err := models.ParseTime(request.expireTime)
+	if err != nil {
+		return nil, errValidation("expire_time must be an ISO 8601 time")
+	}
+	if request.clientOrderID == "" {
+		request.clientOrderID = fmt.Sprintf("fake%d", exchange.lastID+1)
+	}
+	for _, order := range exchange.activeOrders {
+		if order.ClientOrderID == request.clientOrderID {
+			return nil, newAPIError(http.StatusBadRequest, models.ErrorCodeDuplicateClientOrderID, "Duplicate clientOrderId", "")
+		}
+	}
+	quantity, price := amountOf(request.quantity), amountOf(request.price)
+	fillPrice, crosses := exchange.bestPrice(request.symbol, request.side, price, isLimit)
+	if !isLimit && !crosses {
+		return nil, newAPIError(http.StatusBadRequest, 20003, "Limit exceeded", "no liquidity for a market order")
+	}
+	reservePrice := price
+	if !isLimit {
+		reservePrice = fillPrice
+	}
+	currency, amount := symbol.BaseCurrency, quantity
+	if request.side == args.SideBuy {
+		currency, amount = symbol.QuoteCurrency, quantity.Mul(reservePrice)
+	}
+	balance := balanceOf(exchange.spotBalances, currency)
+	if balance.Available.LessThan(amount) {
+		return nil, errInsufficientFunds()
+	}
+	timestamp := exchange.timestamp()
+	order := &models.Order{
+		ID:            exchange.nextID(),
+		ClientOrderID: request.clientOrderID,
+		Symbol:        request.symbol,
+		Side:          string(request.side),
+		Status:        args.OrderStatusNew,
+		Type:          request.orderType,
+		TimeInForce:   request.timeInForce,
+		Quantity:      quantity,
+		Price:         price,
+		StopPrice:     amountOf(request.stopPrice),
+		ExpireTime:    expireTime,
+		PostOnly:      request.postOnly,
+		CreatedAt:     timestamp,
+		UpdatedAt:     timestamp,
+	}
+	switch {
+	case crosses && request.postOnly:
+		order.Status = args.OrderStatusCanceled
+		exchange.finishOrder(order, args.ReportCanceled)
+	case crosses:
+		exchange.fillOrder(order, symbol, fillPrice)
+	case request.timeInForce == args.TimeInForceIOC || request.timeInForce == args.TimeInForceFOK:
+		order.Status = args.OrderStatusExpired
+		exchange.finishOrder(order, args.ReportExpired)
+	default:
+		exchange.reserve(balance, amount)
+		exchange.activeOrders = append(exchange.activeOrders, order)
+		exchange.notify(order, args.ReportNew)
+	}
+	result := *order
+	return &result, nil
+}
+
+// bestPrice returns the best price of the opposite side of the book, and whether the order crosses it
+func (exchange *exchange) bestPrice(symbol string, side args.SideType, limit decimal.Decimal, isLimit bool) (decimal.Decimal, bool) {
+	book := exchange.orderBooks[symbol]
+	levels := book.Ask
+	if side == args.SideSell {
+		levels = book.Bid
+	}
+	var best decimal.Decimal
+	if len(levels) > 0 {
+		best = levels[0].Price
+	} else if ticker, ok := exchange.tickers[symbol]; ok && !isLimit {
+		best = ticker.GetLast()
+	}
+	if best.IsZero() {
+		return best, false
+	}
+	if !isLimit {
+		return best, true
+	}
+	if side == args.SideBuy {
+		return best, !limit.LessThan(best)
+	}
+	return best, !limit.GreaterThan(best)
+}
+
+func (exchange *exchange) reserve(balance *models.Balance, amount decimal.Decimal) {
+	balance.Available = balance.Available.Sub(amount)
+	balance.Reserved = balance.Reserved.Add(amount)
+}
+
+func (exchange *exchange) release(balance *models.Balance, amount decimal.Decimal) {
+	balance.Available = balance.Available.Add(amount)
+	balance.Reserved = balance.Reserved.Sub(amount)
+}
+
+// fillOrder fills the whole order at the price, moving the balances of the account
+func (exchange *exchange) fillOrder(order *models.Order, symbol models.Symbol, price decimal.Decimal) {
+	base := balanceOf(exchange.spotBalances, symbol.BaseCurrency)
+	quote := balanceOf(exchange.spotBalances, symbol.QuoteCurrency)
+	total := order.Quantity.Mul(price)
+	if order.Side == string(args.SideBuy) {
+		quote.Available = quote.Available.Sub(total)
+		base.Available = base.Available.Add(order.Quantity)
+	} else {
+		base.Available = base.Available.Sub(order.Quantity)
+		quote.Available = quote.Available.Add(total)
+	}
+	timestamp := exchange.timestamp()
+	trade := models.Trade{
+		ID:            exchange.nextID(),
+		OrderID:       order.ID,
+		ClientOrderID: order.ClientOrderID,
+		Symbol:        order.Symbol,
+		Side:          args.SideType(order.Side),
+		Quantity:      order.Quantity,
+		Price:         price,
+		Timestamp:     timestamp,
+		Taker:         true,
+	}
+	exchange.tradeHistory = append(exchange.tradeHistory, trade)
+	order.Status = args.OrderStatusFilled
+	order.QuantityCumulative = order.Quantity
+	order.AveragePrice = price
+	order.UpdatedAt = timestamp
+	order.Trades = append(order.Trades, models.TradeOfOrder{
+		ID:        trade.ID,
+		Price:     price,
+		Quantity:  order.Quantity,
+		Taker:     true,
+		Timestamp: timestamp,
+	})
+	exchange.finishOrder(order, args.ReportTrade)
+}
+
+// finishOrder moves an order that is not active anymore to the history
+func (exchange *exchange) finishOrder(order *models.Order, reportType args.ReportType) {
+	exchange.orderHistory = append(exchange.orderHistory, *order)
+	exchange.notify(order, reportType)
+}
+
+func (exchange *exchange) findActiveOrder(clientOrderID string) (int, *models.Order) {
+	for i, order := range exchange.activeOrders {
+		if order.ClientOrderID == clientOrderID {
+			return i, order
+		}
+	}
+	return -1, nil
+}
+
+// reservedOf returns the balance and the amount reserved by an active order
+func (exchange *exchange) reservedOf(order *models.Order) (*models.Balance, decimal.Decimal) {
+	symbol := exchange.symbols[order.Symbol]
+	if order.Side == string(args.SideBuy) {
+		return balanceOf(exchange.spotBalances, symbol.QuoteCurrency), order.Quantity.Mul(order.Price)
+	}
+	return balanceOf(exchange.spotBalances, symbol.BaseCurrency), order.Quantity
+}
+
+func (exchange *exchange) cancelOrder(clientOrderID string) (*models.Order, *apiError) {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	index, order := exchange.findActiveOrder(clientOrderID)
+	if order == nil {
+		return nil, errOrderNotFound(clientOrderID)
+	}
+	exchange.cancelAt(index, order)
+	result := *order
+	return &result, nil
+}
+
+func (exchange *exchange) cancelAt(index int, order *models.Order) {
+	balance, amount := exchange.reservedOf(order)
+	exchange.release(balance, amount)
+	exchange.activeOrders = append(exchange.activeOrders[:index], exchange.activeOrders[index+1:]...)
+	order.Status = args.OrderStatusCanceled
+	order.UpdatedAt = exchange.timestamp()
+	exchange.finishOrder(order, args.ReportCanceled)
+}
+
+func (exchange *exchange) cancelOrders(symbol string) []models.Order {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	canceled := make([]models.Order, 0)
+	for i := len(exchange.activeOrders) - 1; i >= 0; i-- {
+		order := exchange.activeOrders[i]
+		if symbol != "" && order.Symbol != symbol {
+			continue
+		}
+		exchange.cancelAt(i, order)
+		canceled = append(canceled, *order)
+	}
+	return canceled
+}
+
+func (exchange *exchange) replaceOrder(clientOrderID, newClientOrderID, quantity, price string) (*models.Order, *apiError) {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	index, order := exchange.findActiveOrder(clientOrderID)
+	if order == nil {
+		return nil, errOrderNotFound(clientOrderID)
+	}
+	if _, other := exchange.findActiveOrder(newClientOrderID); other != nil {
+		return nil, newAPIError(http.StatusBadRequest, models.ErrorCodeDuplicateClientOrderID, "Duplicate clientOrderId", "")
+	}
+	balance, reserved := exchange.reservedOf(order)
+	replacement := *order
+	replacement.Quantity = amountOf(quantity)
+	if price != "" {
+		replacement.Price = amountOf(price)
+	}
+	_, needed := exchange.reservedOf(&replacement)
+	if balance.Available.Add(reserved).LessThan(needed) {
+		return nil, errInsufficientFunds()
+	}
+	exchange.release(balance, reserved)
+	exchange.reserve(balance, needed)
+	replacement.ID = exchange.nextID()
+	replacement.ClientOrderID = newClientOrderID
+	replacement.OriginalClientOrderID = clientOrderID
+	replacement.UpdatedAt = exchange.timestamp()
+	exchange.activeOrders[index] = &replacement
+	order.Status = args.OrderStatusCanceled
+	exchange.orderHistory = append(exchange.orderHistory, *order)
+	exchange.notify(&replacement, args.ReportReplaced)
+	result := replacement
+	return &result, nil
+}
+
+func (exchange *exchange) activeOrdersOf(symbol string) []models.Order {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	orders := make([]models.Order, 0, len(exchange.activeOrders))
+	for _, order := range exchange.activeOrders {
+		if symbol == "" || order.Symbol == symbol {
+			orders = append(orders, *order)
+		}
+	}
+	return orders
+}
+
+func (exchange *exchange) activeOrder(clientOrderID string) (*models.Order, *apiError) {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	_, order := exchange.findActiveOrder(clientOrderID)
+	if order == nil {
+		return nil, errOrderNotFound(clientOrderID)
+	}
+	result := *order
+	return &result, nil
+}
+
+// event is a change of the account, with the fields of what changed
+type event struct {
+	report        *models.Report
+	spotBalances  []models.Balance
+	walletBalance *models.Balance
+	transaction   *models.Transaction
+}
+
+// subscribe registers a listener of the changes of the account. Listeners are called
+// with the lock of the exchange held, and must not call the exchange back
+func (exchange *exchange) subscribe(listener func(event)) {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	exchange.listeners = append(exchange.listeners, listener)
+}
+
+func (exchange *exchange) emit(change event) {
+	for _, listener := range exchange.listeners {
+		listener(change)
+	}
+}
+
+func (exchange *exchange) notify(order *models.Order, reportType args.ReportType) {
+	if len(exchange.listeners) == 0 {
+		return
+	}
+	report := reportOf(order, reportType)
+	exchange.emit(event{report: &report, spotBalances: sortedBalances(exchange.spotBalances)})
+}
+
+func (exchange *exchange) notifySpotBalances() {
+	if len(exchange.listeners) == 0 {
+		return
+	}
+	exchange.emit(event{spotBalances: sortedBalances(exchange.spotBalances)})
+}
+
+func (exchange *exchange) notifyWalletBalance(currency string) {
+	if len(exchange.listeners) == 0 {
+		return
+	}
+	balance := *balanceOf(exchange.walletBalances, currency)
+	exchange.emit(event{walletBalance: &balance})
+}
+
+func reportOf(order *models.Order, reportType args.ReportType) models.Report {
+	report := models.Report{
+		ID:                    order.ID,
+		ClientOrderID:         order.ClientOrderID,
+		Symbol:                order.Symbol,
+		Side:                  args.SideType(order.Side),
+		Status:                order.Status,
+		OrderType:             order.Type,
+		TimeInForce:           order.TimeInForce,
+		Quantity:              order.Quantity,
+		Price:                 order.Price,
+		QuantityCumulative:    order.QuantityCumulative,
+		PostOnly:              order.PostOnly,
+		CreatedAt:             order.CreatedAt,
+		UpdatedAt:             order.UpdatedAt,
+		StopPrice:             order.StopPrice,
+		ExpireTime:            order.ExpireTime,
+		OriginalClientOrderID: order.OriginalClientOrderID,
+		ReportType:            reportType,
+	}
+	if reportType == args.ReportTrade && len(order.Trades) > 0 {
+		trade := order.Trades[len(order.Trades)-1]
+		report.TradeID = trade.ID
+		report.TradeQuantity = trade.Quantity
+		report.TradePrice = trade.Price
+		report.TradeFee = trade.Fee
+		report.TradeTaker = trade.Taker
+	}
+	return report
+}
+
+// transfer moves funds between the wallet and the spot balances
+func (exchange *exchange) transfer(currency, amount string, source, destination args.AccountType) (string, *apiError) {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	if _, ok := exchange.currencies[currency]; !ok {
+		return "", errCurrencyNotFound(currency)
+	}
+	value, ok := parseAmount(amount)
+	if !ok || value.Sign() <= 0 {
+		return "", errValidation("amount must be a positive number")
+	}
+	accounts := map[args.AccountType]map[string]*models.Balance{
+		args.AccountWallet: exchange.walletBalances,
+		args.AccountSpot:   exchange.spotBalances,
+	}
+	from, okFrom := accounts[source]
+	to, okTo := accounts[destination]
+	if !okFrom || !okTo || source == destination {
+		return "", errValidation("source and destination must be wallet and spot")
+	}
+	fromBalance := balanceOf(from, currency)
+	if fromBalance.Available.LessThan(value) {
+		return "", errInsufficientFunds()
+	}
+	toBalance := balanceOf(to, currency)
+	fromBalance.Available = fromBalance.Available.Sub(value)
+	toBalance.Available = toBalance.Available.Add(value)
+	subType := args.TransactionSubTypeWalletToSpot
+	if source == args.AccountSpot {
+		subType = args.TransactionSubTypeSpotToWallet
+	}
+	transaction := exchange.addTransaction(args.TransactionTypeTransfer, subType, currency, value)
+	exchange.notifyWalletBalance(currency)
+	exchange.notifySpotBalances()
+	return transaction.Native.ID, nil
+}
+
+// withdraw takes the amount from the wallet in a successful withdraw transaction
+func (exchange *exchange) withdraw(currency, amount, address string) (string, *apiError) {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	if _, ok := exchange.currencies[currency]; !ok {
+		return "", errCurrencyNotFound(currency)
+	}
+	value, ok := parseAmount(amount)
+	if !ok || value.Sign() <= 0 {
+		return "", errValidation("amount must be a positive number")
+	}
+	balance := balanceOf(exchange.walletBalances, currency)
+	if balance.Available.LessThan(value) {
+		return "", errInsufficientFunds()
+	}
+	balance.Available = balance.Available.Sub(value)
+	transaction := exchange.newTransaction(args.TransactionTypeWithdraw, args.TransactionSubTypeBlockchain, currency, value)
+	transaction.Native.Address = address
+	exchange.appendTransaction(transaction)
+	exchange.notifyWalletBalance(currency)
+	return transaction.Native.ID, nil
+}
+
+func (exchange *exchange) addTransaction(
+	transactionType args.TransactionTypeType,
+	subType args.TransactionSubTypeType,
+	currency string,
+	amount decimal.Decimal,
+) *models.Transaction {
+	transaction := exchange.newTransaction(transactionType, subType, currency, amount)
+	exchange.appendTransaction(transaction)
+	return transaction
+}
+
+func (exchange *exchange) appendTransaction(transaction *models.Transaction) {
+	exchange.transactions = append(exchange.transactions, *transaction)
+	if len(exchange.listeners) > 0 {
+		exchange.emit(event{transaction: transaction})
+	}
+}
+
+func (exchange *exchange) newTransaction(
+	transactionType args.TransactionTypeType,
+	subType args.TransactionSubTypeType,
+	currency string,
+	amount decimal.Decimal,
+) *models.Transaction {
+	timestamp := exchange.timestamp()
+	id := exchange.nextID()
+	transaction := models.Transaction{
+		ID:             id,
+		Status:         args.TransactionStatusSuccess,
+		Type:           transactionType,
+		SubType:        subType,
+		CreatedAt:      timestamp,
+		UpdatedAt:      timestamp,
+		LastActivityAt: timestamp,
+		Native: models.NativeTransaction{
+			ID:       fmt.Sprintf("%08x-0000-0000-0000-000000000000", id),
+			Currency: currency,
+			Amount:   amount,
+		},
+	}
+	return &transaction
+}
+
+func (exchange *exchange) depositAddress(currency string, renew bool) (models.CryptoAddress, *apiError) {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	if _, ok := exchange.currencies[currency]; !ok {
+		return models.CryptoAddress{}, errCurrencyNotFound(currency)
+	}
+	address, ok := exchange.addresses[currency]
+	if !ok || renew {
+		address = models.CryptoAddress{
+			Currency: currency,
+			Address:  fmt.Sprintf("%s-address-%d", strings.ToLower(currency), exchange.nextID()),
+		}
+		exchange.addresses[currency] = address
+	}
+	return address, nil
+}
+
+func (exchange *exchange) findSubAccount(id string) *subAccount {
+	for _, account := range exchange.subAccounts {
+		if account.ID == id {
+			return account
+		}
+	}
+	return nil
+}
+
+func errSubAccountNotFound(id string) *apiError {
+	return newAPIError(http.StatusBadRequest, 10001, "Validation error", "no sub-account "+id)
+}
+
+func (exchange *exchange) setSubAccountsStatus(ids []string, status string) *apiError {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	for _, id := range ids {
+		if exchange.findSubAccount(id) == nil {
+			return errSubAccountNotFound(id)
+		}
+	}
+	for _, id := range ids {
+		exchange.findSubAccount(id).Status = status
+	}
+	return nil
+}
+
+// transferWithSubAccount moves funds between the wallet of the account and the wallet of a sub-account
+func (exchange *exchange) transferWithSubAccount(id, currency, amount string, transferType args.TransferTypeType) *apiError {
+	exchange.lock.Lock()
+	defer exchange.lock.Unlock()
+	account := exchange.findSubAccount(id)
+	if account == nil {
+		return errSubAccountNotFound(id)
+	}
+	value, ok := parseAmount(amount)
+	if !ok || value.Sign() <= 0 {
+		return errValidation("amount must be a positive number")
+	}
+	subBalances := make(map[string]*models.Balance)
+	for i := range account.balances.Wallet {
+		subBalances[account.balances.Wallet[i].Currency] = &account.balances.Wallet[i]
+	}
+	from, to := exchange.walletBalances, subBalances
+	if transferType == args.TransferFromSubAccount {
+		from, to = subBalances, exchange.walletBalances
+	}
+	fromBalance := balanceOf(from, currency)
+	if fromBalance.Available.LessThan(value) {
+		return errInsufficientFunds()
+	}
+	toBalance := balanceOf(to, currency)
+	fromBalance.Available = fromBalance.Available.Sub(value)
+	toBalance.Available = toBalance.Available.Add(value)
+	account.balances.Wallet = sortedBalances(subBalances)
+	exchange.addTransaction(args.TransactionTypeTransfer, args.TransactionSubTypeSubAccount, currency, value)
+	exchange.notifyWalletBalance(currency)
+	return nil
+}
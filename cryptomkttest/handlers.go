@@ -0,0 +1,677 @@
+package cryptomkttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+const apiPrefix = "/api/3/"
+
+// restRequest is a request to a route, with its params and the variables of its path
+type restRequest struct {
+	params map[string]string
+	vars   map[string]string
+}
+
+type handler func(server *Server, request *restRequest) (interface{}, *apiError)
+
+type route struct {
+	method  string
+	pattern []string
+	public  bool
+	handler handler
+}
+
+var routes []route
+
+func handle(method, pattern string, public bool, handler handler) {
+	routes = append(routes, route{
+		method:  method,
+		pattern: strings.Split(pattern, "/"),
+		public:  public,
+		handler: handler,
+	})
+}
+
+func init() {
+	// public
+	handle(http.MethodGet, "public/currency", true, getCurrencies)
+	handle(http.MethodGet, "public/currency/{currency}", true, getCurrency)
+	handle(http.MethodGet, "public/symbol", true, getSymbols)
+	handle(http.MethodGet, "public/symbol/{symbol}", true, getSymbol)
+	handle(http.MethodGet, "public/ticker", true, getTickers)
+	handle(http.MethodGet, "public/ticker/{symbol}", true, getTicker)
+	handle(http.MethodGet, "public/orderbook", true, getOrderBooks)
+	handle(http.MethodGet, "public/orderbook/{symbol}", true, getOrderBook)
+	handle(http.MethodGet, "public/trades", true, getPublicTrades)
+	handle(http.MethodGet, "public/trades/{symbol}", true, getPublicTradesOfSymbol)
+	handle(http.MethodGet, "public/candles", true, getCandles)
+	handle(http.MethodGet, "public/candles/{symbol}", true, getCandlesOfSymbol)
+	// spot trading
+	handle(http.MethodGet, "spot/balance", false, getSpotBalances)
+	handle(http.MethodGet, "spot/balance/{currency}", false, getSpotBalance)
+	handle(http.MethodGet, "spot/order", false, getActiveOrders)
+	handle(http.MethodGet, "spot/order/{client_order_id}", false, getActiveOrder)
+	handle(http.MethodPost, "spot/order", false, createOrder)
+	handle(http.MethodPatch, "spot/order/{client_order_id}", false, replaceOrder)
+	handle(http.MethodDelete, "spot/order", false, cancelOrders)
+	handle(http.MethodDelete, "spot/order/{client_order_id}", false, cancelOrder)
+	handle(http.MethodGet, "spot/fee", false, getTradingCommissions)
+	handle(http.MethodGet, "spot/fee/{symbol}", false, getTradingCommission)
+	handle(http.MethodGet, "spot/history/order", false, getOrderHistory)
+	handle(http.MethodGet, "spot/history/trade", false, getTradeHistory)
+	// wallet
+	handle(http.MethodGet, "wallet/balance", false, getWalletBalances)
+	handle(http.MethodGet, "wallet/balance/{currency}", false, getWalletBalance)
+	handle(http.MethodGet, "wallet/crypto/address", false, getDepositAddresses)
+	handle(http.MethodPost, "wallet/crypto/address", false, createDepositAddress)
+	handle(http.MethodPost, "wallet/crypto/withdraw", false, withdrawCrypto)
+	handle(http.MethodPost, "wallet/transfer", false, transfer)
+	handle(http.MethodGet, "wallet/transactions", false, getTransactions)
+	handle(http.MethodGet, "wallet/transactions/{id}", false, getTransaction)
+	// sub-accounts
+	handle(http.MethodGet, "sub-account", false, getSubAccounts)
+	handle(http.MethodPost, "sub-account/freeze", false, freezeSubAccounts)
+	handle(http.MethodPost, "sub-account/activate", false, activateSubAccounts)
+	handle(http.MethodPost, "sub-account/transfer", false, transferWithSubAccount)
+	handle(http.MethodGet, "sub-account/acl", false, getACLSettings)
+	handle(http.MethodPost, "sub-account/acl", false, changeACLSettings)
+	handle(http.MethodGet, "sub-account/balance/{sub_account_id}", false, getSubAccountBalances)
+	handle(http.MethodGet, "sub-account/crypto/address/{sub_account_id}/{currency}", false, getSubAccountAddress)
+}
+
+func (route route) match(method string, segments []string) (map[string]string, bool) {
+	if route.method != method || len(route.pattern) != len(segments) {
+		return nil, false
+	}
+	vars := make(map[string]string)
+	for i, part := range route.pattern {
+		if strings.HasPrefix(part, "{") {
+			vars[strings.Trim(part, "{}")] = segments[i]
+			continue
+		}
+		if part != segments[i] {
+			return nil, false
+		}
+	}
+	return vars, true
+}
+
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, wsPrefix) {
+		server.serveWebsocket(w, r)
+		return
+	}
+	w.Header().Set("Date", server.now().UTC().Format(http.TimeFormat))
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, newAPIError(http.StatusBadRequest, 400, "Bad request", err.Error()))
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, errNotFound(r.URL.Path))
+		return
+	}
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, apiPrefix), "/")
+	for _, route := range routes {
+		vars, ok := route.match(r.Method, segments)
+		if !ok {
+			continue
+		}
+		if !route.public {
+			if apiError := server.authenticate(r, body); apiError != nil {
+				writeError(w, apiError)
+				return
+			}
+		}
+		params, apiError := paramsOf(r, body)
+		if apiError != nil {
+			writeError(w, apiError)
+			return
+		}
+		result, apiError := route.handler(server, &restRequest{params: params, vars: vars})
+		if apiError != nil {
+			writeError(w, apiError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+	writeError(w, errNotFound(r.URL.Path))
+}
+
+func errNotFound(path string) *apiError {
+	return newAPIError(http.StatusNotFound, http.StatusNotFound, "Not found", "no route for "+path)
+}
+
+func writeError(w http.ResponseWriter, apiError *apiError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiError.status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"error": apiError.APIError})
+}
+
+// paramsOf returns the params of the query of GET requests, of the json body of POST
+// requests and of the url encoded body of the rest, with lists joined by commas
+func paramsOf(r *http.Request, body []byte) (map[string]string, *apiError) {
+	params := make(map[string]string)
+	if r.Method == http.MethodPost {
+		if len(bytes.TrimSpace(body)) == 0 {
+			return params, nil
+		}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		values := make(map[string]interface{})
+		if err := decoder.Decode(&values); err != nil {
+			return nil, errValidation("malformed json body")
+		}
+		for key, value := range values {
+			params[key] = stringOf(value)
+		}
+		return params, nil
+	}
+	raw := r.URL.RawQuery
+	if r.Method != http.MethodGet {
+		raw = string(body)
+	}
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return nil, errValidation("malformed params")
+	}
+	for key := range values {
+		params[key] = values.Get(key)
+	}
+	return params, nil
+}
+
+func stringOf(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, item := range v {
+			values[i] = stringOf(item)
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// listOf splits a comma separated param. Empty for missing params
+func listOf(param string) []string {
+	if param == "" {
+		return nil
+	}
+	return strings.Split(param, ",")
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// filterByKeys returns the values of the map with the given keys, or all if no keys are given
+func filterByKeys[T any](values map[string]T, keys []string) map[string]T {
+	result := make(map[string]T)
+	for key, value := range values {
+		if len(keys) == 0 || contains(keys, key) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func getCurrencies(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	return filterByKeys(server.exchange.currencies, listOf(request.params["currencies"])), nil
+}
+
+func getCurrency(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	currency, ok := server.exchange.currencies[request.vars["currency"]]
+	if !ok {
+		return nil, errCurrencyNotFound(request.vars["currency"])
+	}
+	return currency, nil
+}
+
+func getSymbols(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	return filterByKeys(server.exchange.symbols, listOf(request.params["symbols"])), nil
+}
+
+func getSymbol(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	symbol, ok := server.exchange.symbols[request.vars["symbol"]]
+	if !ok {
+		return nil, errSymbolNotFound(request.vars["symbol"])
+	}
+	return symbol, nil
+}
+
+func getTickers(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	return filterByKeys(server.exchange.tickers, listOf(request.params["symbols"])), nil
+}
+
+func getTicker(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	ticker, ok := server.exchange.tickers[request.vars["symbol"]]
+	if !ok {
+		return nil, errSymbolNotFound(request.vars["symbol"])
+	}
+	return ticker, nil
+}
+
+// bookJSON returns the order book as sent by the exchange, up to the depth. 0 is the full book
+func bookJSON(book models.OrderBook, depth int) models.OrderBookJson {
+	levels := func(levels []models.BookLevel) [][]decimal.Decimal {
+		result := make([][]decimal.Decimal, 0, len(levels))
+		for i, level := range levels {
+			if depth > 0 && i >= depth {
+				break
+			}
+			result = append(result, []decimal.Decimal{level.Price, level.Amount})
+		}
+		return result
+	}
+	return models.OrderBookJson{Ask: levels(book.Ask), Bid: levels(book.Bid), Timestamp: book.Timestamp}
+}
+
+func depthOf(params map[string]string) int {
+	depth := 100
+	if value, ok := params["depth"]; ok {
+		fmt.Sscan(value, &depth)
+	}
+	return depth
+}
+
+func getOrderBooks(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	depth := depthOf(request.params)
+	result := make(map[string]models.OrderBookJson)
+	for symbol, book := range filterByKeys(server.exchange.orderBooks, listOf(request.params["symbols"])) {
+		result[symbol] = bookJSON(book, depth)
+	}
+	return result, nil
+}
+
+func getOrderBook(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	book, ok := server.exchange.orderBooks[request.vars["symbol"]]
+	if !ok {
+		if _, ok := server.exchange.symbols[request.vars["symbol"]]; !ok {
+			return nil, errSymbolNotFound(request.vars["symbol"])
+		}
+	}
+	return bookJSON(book, depthOf(request.params)), nil
+}
+
+func getPublicTrades(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	result := make(map[string][]models.PublicTrade)
+	for symbol, trades := range filterByKeys(server.exchange.publicTrades, listOf(request.params["symbols"])) {
+		result[symbol] = pageOf(trades, request.params, publicTradeKeys)
+	}
+	return result, nil
+}
+
+func getPublicTradesOfSymbol(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	if _, ok := server.exchange.symbols[request.vars["symbol"]]; !ok {
+		return nil, errSymbolNotFound(request.vars["symbol"])
+	}
+	return pageOf(server.exchange.publicTrades[request.vars["symbol"]], request.params, publicTradeKeys), nil
+}
+
+func getCandles(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	result := make(map[string][]models.Candle)
+	for symbol, candles := range filterByKeys(server.exchange.candles, listOf(request.params["symbols"])) {
+		result[symbol] = pageOf(candles, request.params, candleKeys)
+	}
+	return result, nil
+}
+
+func getCandlesOfSymbol(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	if _, ok := server.exchange.symbols[request.vars["symbol"]]; !ok {
+		return nil, errSymbolNotFound(request.vars["symbol"])
+	}
+	return pageOf(server.exchange.candles[request.vars["symbol"]], request.params, candleKeys), nil
+}
+
+func getSpotBalances(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	return sortedBalances(server.exchange.spotBalances), nil
+}
+
+func getSpotBalance(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	if _, ok := server.exchange.currencies[request.vars["currency"]]; !ok {
+		return nil, errCurrencyNotFound(request.vars["currency"])
+	}
+	return *balanceOf(server.exchange.spotBalances, request.vars["currency"]), nil
+}
+
+func getActiveOrders(server *Server, request *restRequest) (interface{}, *apiError) {
+	return server.exchange.activeOrdersOf(request.params["symbol"]), nil
+}
+
+func getActiveOrder(server *Server, request *restRequest) (interface{}, *apiError) {
+	return server.exchange.activeOrder(request.vars["client_order_id"])
+}
+
+func createOrder(server *Server, request *restRequest) (interface{}, *apiError) {
+	return server.exchange.createOrder(orderRequestOf(request.params))
+}
+
+func replaceOrder(server *Server, request *restRequest) (interface{}, *apiError) {
+	return server.exchange.replaceOrder(
+		request.vars["client_order_id"],
+		request.params["new_client_order_id"],
+		request.params["quantity"],
+		request.params["price"],
+	)
+}
+
+func cancelOrders(server *Server, request *restRequest) (interface{}, *apiError) {
+	return server.exchange.cancelOrders(request.params["symbol"]), nil
+}
+
+func cancelOrder(server *Server, request *restRequest) (interface{}, *apiError) {
+	return server.exchange.cancelOrder(request.vars["client_order_id"])
+}
+
+func commissionOf(id string, symbol models.Symbol) models.TradingCommission {
+	return models.TradingCommission{Symbol: id, TakeRate: symbol.TakeRate, MakeRate: symbol.MakeRate}
+}
+
+func getTradingCommissions(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	commissions := make([]models.TradingCommission, 0, len(server.exchange.symbols))
+	for id, symbol := range server.exchange.symbols {
+		commissions = append(commissions, commissionOf(id, symbol))
+	}
+	return commissions, nil
+}
+
+func getTradingCommission(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	symbol, ok := server.exchange.symbols[request.vars["symbol"]]
+	if !ok {
+		return nil, errSymbolNotFound(request.vars["symbol"])
+	}
+	return commissionOf(request.vars["symbol"], symbol), nil
+}
+
+func getOrderHistory(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	orders := make([]models.Order, 0)
+	for _, order := range server.exchange.orderHistory {
+		if symbol := request.params["symbol"]; symbol != "" && order.Symbol != symbol {
+			continue
+		}
+		if clientOrderID := request.params["client_order_id"]; clientOrderID != "" && order.ClientOrderID != clientOrderID {
+			continue
+		}
+		orders = append(orders, order)
+	}
+	return pageOf(orders, request.params, orderKeys), nil
+}
+
+func getTradeHistory(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	trades := make([]models.Trade, 0)
+	for _, trade := range server.exchange.tradeHistory {
+		if symbol := request.params["symbol"]; symbol != "" && trade.Symbol != symbol {
+			continue
+		}
+		if orderID := request.params["order_id"]; orderID != "" && fmt.Sprint(trade.OrderID) != orderID {
+			continue
+		}
+		trades = append(trades, trade)
+	}
+	return pageOf(trades, request.params, tradeKeys), nil
+}
+
+func getWalletBalances(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	return sortedBalances(server.exchange.walletBalances), nil
+}
+
+func getWalletBalance(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	if _, ok := server.exchange.currencies[request.vars["currency"]]; !ok {
+		return nil, errCurrencyNotFound(request.vars["currency"])
+	}
+	return *balanceOf(server.exchange.walletBalances, request.vars["currency"]), nil
+}
+
+func getDepositAddresses(server *Server, request *restRequest) (interface{}, *apiError) {
+	if currency := request.params["currency"]; currency != "" {
+		address, apiError := server.exchange.depositAddress(currency, false)
+		if apiError != nil {
+			return nil, apiError
+		}
+		return []models.CryptoAddress{address}, nil
+	}
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	addresses := make([]models.CryptoAddress, 0, len(server.exchange.addresses))
+	for _, address := range server.exchange.addresses {
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+func createDepositAddress(server *Server, request *restRequest) (interface{}, *apiError) {
+	return server.exchange.depositAddress(request.params["currency"], true)
+}
+
+func withdrawCrypto(server *Server, request *restRequest) (interface{}, *apiError) {
+	id, apiError := server.exchange.withdraw(request.params["currency"], request.params["amount"], request.params["address"])
+	if apiError != nil {
+		return nil, apiError
+	}
+	return models.IDResponse{ID: id}, nil
+}
+
+func transfer(server *Server, request *restRequest) (interface{}, *apiError) {
+	id, apiError := server.exchange.transfer(
+		request.params["currency"],
+		request.params["amount"],
+		args.AccountType(request.params["source"]),
+		args.AccountType(request.params["destination"]),
+	)
+	if apiError != nil {
+		return nil, apiError
+	}
+	return []string{id}, nil
+}
+
+func getTransactions(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	currencies := listOf(request.params["currencies"])
+	types := listOf(request.params["types"])
+	subTypes := listOf(request.params["subtypes"])
+	statuses := listOf(request.params["statuses"])
+	ids := listOf(request.params["tx_ids"])
+	transactions := make([]models.Transaction, 0)
+	for _, transaction := range server.exchange.transactions {
+		switch {
+		case len(currencies) > 0 && !contains(currencies, transaction.Native.Currency),
+			len(types) > 0 && !contains(types, string(transaction.Type)),
+			len(subTypes) > 0 && !contains(subTypes, string(transaction.SubType)),
+			len(statuses) > 0 && !contains(statuses, string(transaction.Status)),
+			len(ids) > 0 && !contains(ids, transaction.Native.ID):
+			continue
+		}
+		transactions = append(transactions, transaction)
+	}
+	return pageOf(transactions, request.params, transactionKeys), nil
+}
+
+func getTransaction(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	id := request.vars["id"]
+	for _, transaction := range server.exchange.transactions {
+		if transaction.Native.ID == id || fmt.Sprint(transaction.ID) == id {
+			return transaction, nil
+		}
+	}
+	return nil, newAPIError(http.StatusNotFound, 20005, "Transaction not found", "no transaction "+id)
+}
+
+type resultResponse struct {
+	Result interface{} `json:"result"`
+}
+
+func getSubAccounts(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	accounts := make([]models.SubAccount, len(server.exchange.subAccounts))
+	for i, account := range server.exchange.subAccounts {
+		accounts[i] = account.SubAccount
+	}
+	return resultResponse{accounts}, nil
+}
+
+func freezeSubAccounts(server *Server, request *restRequest) (interface{}, *apiError) {
+	if apiError := server.exchange.setSubAccountsStatus(listOf(request.params["sub_account_ids"]), "disable"); apiError != nil {
+		return nil, apiError
+	}
+	return resultResponse{true}, nil
+}
+
+func activateSubAccounts(server *Server, request *restRequest) (interface{}, *apiError) {
+	if apiError := server.exchange.setSubAccountsStatus(listOf(request.params["sub_account_ids"]), "active"); apiError != nil {
+		return nil, apiError
+	}
+	return resultResponse{true}, nil
+}
+
+func transferWithSubAccount(server *Server, request *restRequest) (interface{}, *apiError) {
+	apiError := server.exchange.transferWithSubAccount(
+		request.params["sub_account_id"],
+		request.params["currency"],
+		request.params["amount"],
+		args.TransferTypeType(request.params["type"]),
+	)
+	if apiError != nil {
+		return nil, apiError
+	}
+	return resultResponse{true}, nil
+}
+
+// subAccountIDsOf returns the ids of the sub_account_ids param, or of the sub_account_id param
+func subAccountIDsOf(params map[string]string) []string {
+	if ids := listOf(params["sub_account_ids"]); len(ids) > 0 {
+		return ids
+	}
+	return listOf(params["sub_account_id"])
+}
+
+func getACLSettings(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	ids := subAccountIDsOf(request.params)
+	settings := make([]models.ACLSettings, 0)
+	for _, account := range server.exchange.subAccounts {
+		if len(ids) == 0 || contains(ids, account.ID) {
+			settings = append(settings, account.acl)
+		}
+	}
+	return resultResponse{settings}, nil
+}
+
+func changeACLSettings(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	ids := subAccountIDsOf(request.params)
+	for _, id := range ids {
+		if server.exchange.findSubAccount(id) == nil {
+			return nil, errSubAccountNotFound(id)
+		}
+	}
+	timestamp := server.exchange.timestamp()
+	for _, id := range ids {
+		acl := &server.exchange.findSubAccount(id).acl
+		if value, ok := request.params["deposit_address_generation_enabled"]; ok {
+			acl.DepositAddressGenerationEnabled = value == "true"
+		}
+		if value, ok := request.params["withdraw_enabled"]; ok {
+			acl.WithdrawEnabled = value == "true"
+		}
+		if value, ok := request.params["description"]; ok {
+			acl.Description = value
+		}
+		if acl.CreatedAt.IsZero() {
+			acl.CreatedAt = timestamp
+		}
+		acl.UpdatedAt = timestamp
+	}
+	return resultResponse{true}, nil
+}
+
+func getSubAccountBalances(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	account := server.exchange.findSubAccount(request.vars["sub_account_id"])
+	if account == nil {
+		return nil, errSubAccountNotFound(request.vars["sub_account_id"])
+	}
+	return account.balances, nil
+}
+
+func getSubAccountAddress(server *Server, request *restRequest) (interface{}, *apiError) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	id := request.vars["sub_account_id"]
+	if server.exchange.findSubAccount(id) == nil {
+		return nil, errSubAccountNotFound(id)
+	}
+	currency := request.vars["currency"]
+	if _, ok := server.exchange.currencies[currency]; !ok {
+		return nil, errCurrencyNotFound(currency)
+	}
+	address := fmt.Sprintf("%s-address-of-%s", strings.ToLower(currency), id)
+	return resultResponse{map[string]string{"address": address}}, nil
+}
@@ -0,0 +1,113 @@
+package cryptomkttest
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// keys returns the timestamp and the id items are sorted and filtered by
+type keys[T any] func(item T) (timestamp time.Time, id int64)
+
+func publicTradeKeys(trade models.PublicTrade) (time.Time, int64) {
+	return trade.Timestamp.Time, trade.ID
+}
+func candleKeys(candle models.Candle) (time.Time, int64) { return candle.Timestamp.Time, 0 }
+func orderKeys(order models.Order) (time.Time, int64)    { return order.CreatedAt.Time, order.ID }
+func tradeKeys(trade models.Trade) (time.Time, int64)    { return trade.Timestamp.Time, trade.ID }
+func transactionKeys(transaction models.Transaction) (time.Time, int64) {
+	return transaction.CreatedAt.Time, transaction.ID
+}
+
+// parseTime parses an iso 8601 time or milliseconds since the epoch
+func parseTime(value string) (time.Time, bool) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(millis), true
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	return parsed, err == nil
+}
+
+// pageOf sorts, filters and pages items with the sort, by, order_by, from, till, id_from,
+// id_till, limit and offset params of the exchange
+func pageOf[T any](items []T, params map[string]string, keysOf keys[T]) []T {
+	by := params["by"]
+	if by == "" {
+		by = params["order_by"]
+	}
+	byID := by == "id"
+	idFrom, idTill := params["id_from"], params["id_till"]
+	from, till := params["from"], params["till"]
+	if byID {
+		idFrom, idTill, from, till = from, till, "", ""
+	}
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		timestamp, id := keysOf(item)
+		if !inIDRange(id, idFrom, idTill) || !inTimeRange(timestamp, from, till) {
+			continue
+		}
+		result = append(result, item)
+	}
+	ascending := params["sort"] == "ASC"
+	sort.SliceStable(result, func(i, j int) bool {
+		iTimestamp, iID := keysOf(result[i])
+		jTimestamp, jID := keysOf(result[j])
+		less := iID < jID
+		if !byID && !iTimestamp.Equal(jTimestamp) {
+			less = iTimestamp.Before(jTimestamp)
+		} else if iID == jID {
+			return false
+		}
+		return less == ascending
+	})
+	offset, _ := strconv.Atoi(params["offset"])
+	limit, err := strconv.Atoi(params["limit"])
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	if offset >= len(result) {
+		return result[:0]
+	}
+	result = result[offset:]
+	if limit < len(result) {
+		result = result[:limit]
+	}
+	return result
+}
+
+func inIDRange(id int64, from, till string) bool {
+	if value, err := strconv.ParseInt(from, 10, 64); err == nil && id < value {
+		return false
+	}
+	if value, err := strconv.ParseInt(till, 10, 64); err == nil && id > value {
+		return false
+	}
+	return true
+}
+
+func inTimeRange(at time.Time, from, till string) bool {
+	if from == "" && till == "" {
+		return true
+	}
+	if at.IsZero() {
+		return false
+	}
+	if value, ok := parseTime(from); ok && at.Before(value) {
+		return false
+	}
+	if value, ok := parseTime(till); ok && at.After(value) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,241 @@
+// Package cryptomkttest is an in-process fake of the exchange, for integration tests
+// of code built on the sdk without access to the production exchange.
+//
+// The fake keeps an in-memory state of currencies, symbols, tickers, order books,
+// spot orders, balances, wallet transactions and sub-accounts, and validates the
+// HS256 authentication of private calls.
+//
+// The same server speaks the websocket api of the public, trading and wallet streams.
+// Order reports and balance updates follow the changes of its state, public feeds are
+// scripted with Publish, and FailNext, Disconnect and Stall inject errors and network failures.
+//
+//	server := cryptomkttest.NewServer()
+//	defer server.Close()
+//	server.SetSpotBalance("USDT", "1000")
+//	client := server.Client()
+//	order, err := client.CreateSpotOrder(ctx, args.Symbol("BTCUSDT"), args.Side(args.SideBuy), args.Quantity("0.01"), args.Price("20000"))
+package cryptomkttest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/cryptomkt/cryptomkt-go/v3/rest"
+)
+
+// credentials of the account registered by NewServer
+const (
+	APIKey    = "cryptomkttest-api-key"
+	APISecret = "cryptomkttest-api-secret"
+)
+
+// Server is a fake exchange served by an httptest.Server
+type Server struct {
+	// URL is the base url of the server, to use with rest.WithBaseURL
+	URL string
+
+	server      *httptest.Server
+	exchange    *exchange
+	lock        *sync.RWMutex
+	accounts    map[string]string
+	clockOffset time.Duration
+	sessions    map[*wsSession]bool
+	failures    map[string][]models.APIError
+}
+
+// NewServer starts a fake exchange with the default market of DefaultCurrencies and
+// DefaultSymbols, and an account with the APIKey and APISecret credentials.
+// Call Close when done.
+func NewServer() *Server {
+	server := &Server{
+		exchange: newExchange(),
+		lock:     new(sync.RWMutex),
+		accounts: map[string]string{APIKey: APISecret},
+		sessions: make(map[*wsSession]bool),
+		failures: make(map[string][]models.APIError),
+	}
+	server.exchange.now = server.now
+	server.exchange.subscribe(server.broadcast)
+	server.seed()
+	server.server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	server.URL = server.server.URL
+	return server
+}
+
+// Close shuts down the server
+func (server *Server) Close() {
+	server.Disconnect()
+	server.server.CloseClientConnections()
+	server.server.Close()
+}
+
+// Client returns a rest client authenticated with the APIKey and APISecret credentials,
+// with the given options applied after the ones targeting the server
+func (server *Server) Client(options ...rest.ClientOption) *rest.Client {
+	return rest.NewClientWithOptions(append([]rest.ClientOption{
+		rest.WithBaseURL(server.URL),
+		rest.WithCredentials(APIKey, APISecret),
+	}, options...)...)
+}
+
+// AddAccount registers credentials accepted by the server. All accounts share the same state
+func (server *Server) AddAccount(apiKey, apiSecret string) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.accounts[apiKey] = apiSecret
+}
+
+// SetClockOffset makes the clock of the server run ahead of the local clock by the offset,
+// or behind if negative, to test the handling of clock skew
+func (server *Server) SetClockOffset(offset time.Duration) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.clockOffset = offset
+}
+
+func (server *Server) now() time.Time {
+	server.lock.RLock()
+	defer server.lock.RUnlock()
+	return time.Now().Add(server.clockOffset)
+}
+
+// DefaultCurrencies are the currencies of the market of a new server
+var DefaultCurrencies = map[string]models.Currency{
+	"BTC":  {FullName: "Bitcoin", Crypto: true, PayinEnabled: true, PayoutEnabled: true, TransferEnabled: true, PrecisionTransfer: decimal.MustParse("0.00000001")},
+	"ETH":  {FullName: "Ethereum", Crypto: true, PayinEnabled: true, PayoutEnabled: true, TransferEnabled: true, PrecisionTransfer: decimal.MustParse("0.000000000001")},
+	"USDT": {FullName: "Tether", Crypto: true, PayinEnabled: true, PayoutEnabled: true, TransferEnabled: true, PrecisionTransfer: decimal.MustParse("0.000001")},
+}
+
+// DefaultSymbols are the symbols of the market of a new server
+var DefaultSymbols = map[string]models.Symbol{
+	"BTCUSDT": {Type: "spot", BaseCurrency: "BTC", QuoteCurrency: "USDT", Status: args.SymbolStatusWorking, QuantityIncrement: decimal.MustParse("0.00001"), TickSize: decimal.MustParse("0.01"), TakeRate: decimal.MustParse("0.0025"), MakeRate: decimal.MustParse("0.001"), FeeCurrency: "USDT"},
+	"ETHBTC":  {Type: "spot", BaseCurrency: "ETH", QuoteCurrency: "BTC", Status: args.SymbolStatusWorking, QuantityIncrement: decimal.MustParse("0.0001"), TickSize: decimal.MustParse("0.000001"), TakeRate: decimal.MustParse("0.0025"), MakeRate: decimal.MustParse("0.001"), FeeCurrency: "BTC"},
+	"ETHUSDT": {Type: "spot", BaseCurrency: "ETH", QuoteCurrency: "USDT", Status: args.SymbolStatusWorking, QuantityIncrement: decimal.MustParse("0.0001"), TickSize: decimal.MustParse("0.01"), TakeRate: decimal.MustParse("0.0025"), MakeRate: decimal.MustParse("0.001"), FeeCurrency: "USDT"},
+}
+
+var defaultBooks = map[string][2]string{
+	"BTCUSDT": {"20000", "20010"},
+	"ETHBTC":  {"0.07", "0.0701"},
+	"ETHUSDT": {"1400", "1401"},
+}
+
+func (server *Server) seed() {
+	for id, currency := range DefaultCurrencies {
+		server.SetCurrency(id, currency)
+	}
+	for id, symbol := range DefaultSymbols {
+		server.SetSymbol(id, symbol)
+	}
+	for id, prices := range defaultBooks {
+		bid, ask, amount := decimal.MustParse(prices[0]), decimal.MustParse(prices[1]), decimal.NewFromInt(10)
+		server.SetOrderBook(id, models.OrderBook{
+			Bid: []models.BookLevel{{Price: bid, Amount: amount}},
+			Ask: []models.BookLevel{{Price: ask, Amount: amount}},
+		})
+		server.SetTicker(id, models.Ticker{Bid: bid, Ask: ask, Last: ask, Open: bid, Close: ask, High: ask, Low: bid, Volume: decimal.NewFromInt(100)})
+	}
+}
+
+// SetCurrency adds or replaces a currency
+func (server *Server) SetCurrency(id string, currency models.Currency) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	server.exchange.currencies[id] = currency
+}
+
+// SetSymbol adds or replaces a symbol
+func (server *Server) SetSymbol(id string, symbol models.Symbol) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	server.exchange.symbols[id] = symbol
+}
+
+// SetTicker sets the ticker of a symbol. An empty timestamp is the current time
+func (server *Server) SetTicker(symbol string, ticker models.Ticker) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	if ticker.Timestamp.IsZero() {
+		ticker.Timestamp = server.exchange.timestamp()
+	}
+	server.exchange.tickers[symbol] = ticker
+}
+
+// SetOrderBook sets the order book of a symbol, with asks in ascending and bids in descending
+// order of price. Orders crossing the book are filled at its best price.
+// An empty timestamp is the current time
+func (server *Server) SetOrderBook(symbol string, book models.OrderBook) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	if book.Timestamp.IsZero() {
+		book.Timestamp = server.exchange.timestamp()
+	}
+	server.exchange.orderBooks[symbol] = book
+}
+
+// AddPublicTrades adds trades to the public trades of a symbol
+func (server *Server) AddPublicTrades(symbol string, trades ...models.PublicTrade) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	server.exchange.publicTrades[symbol] = append(server.exchange.publicTrades[symbol], trades...)
+}
+
+// AddCandles adds candles to the candles of a symbol, for all periods
+func (server *Server) AddCandles(symbol string, candles ...models.Candle) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	server.exchange.candles[symbol] = append(server.exchange.candles[symbol], candles...)
+}
+
+// SetSpotBalance sets the available spot balance of a currency. Panics if available is not a decimal
+func (server *Server) SetSpotBalance(currency, available string) {
+	amount := decimal.MustParse(available)
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	balanceOf(server.exchange.spotBalances, currency).Available = amount
+	server.exchange.notifySpotBalances()
+}
+
+// SetWalletBalance sets the available wallet balance of a currency. Panics if available is not a decimal
+func (server *Server) SetWalletBalance(currency, available string) {
+	amount := decimal.MustParse(available)
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	balanceOf(server.exchange.walletBalances, currency).Available = amount
+	server.exchange.notifyWalletBalance(currency)
+}
+
+// AddTransactions adds transactions to the wallet history. A zero id is given a new id
+func (server *Server) AddTransactions(transactions ...models.Transaction) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	for _, transaction := range transactions {
+		if transaction.ID == 0 {
+			transaction.ID = server.exchange.nextID()
+		}
+		server.exchange.appendTransaction(&transaction)
+	}
+}
+
+// AddSubAccount adds a sub-account with the given wallet balances
+func (server *Server) AddSubAccount(account models.SubAccount, walletBalances ...models.Balance) {
+	server.exchange.lock.Lock()
+	defer server.exchange.lock.Unlock()
+	if account.Status == "" {
+		account.Status = "active"
+	}
+	server.exchange.subAccounts = append(server.exchange.subAccounts, &subAccount{
+		SubAccount: account,
+		acl:        models.ACLSettings{SubAccountID: account.ID},
+		balances:   models.SubAccountBalances{Wallet: walletBalances, Spot: []models.Balance{}},
+	})
+}
+
+// ActiveOrders returns the active spot orders of the account
+func (server *Server) ActiveOrders() []models.Order {
+	return server.exchange.activeOrdersOf("")
+}
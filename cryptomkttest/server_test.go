@@ -0,0 +1,272 @@
+package cryptomkttest
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/cryptomkt/cryptomkt-go/v3/rest"
+)
+
+func TestPublicCalls(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+	currencies, err := client.GetCurrencies(ctx, args.Currencies([]string{"BTC", "ETH"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(currencies) != 2 || currencies["BTC"].FullName != "Bitcoin" {
+		t.Fatalf("unexpected currencies %v", currencies)
+	}
+	book, err := client.GetOrderBookOfSymbol(ctx, args.Symbol("BTCUSDT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !book.Bid[0].Price.Equal(decimal.MustParse("20000")) || !book.Ask[0].Price.Equal(decimal.MustParse("20010")) {
+		t.Fatalf("unexpected book %+v", book)
+	}
+	if _, err := client.GetSymbol(ctx, args.Symbol("NOPE")); !errors.Is(err, models.ErrInvalidSymbol) {
+		t.Fatalf("expected symbol not found, got %v", err)
+	}
+}
+
+func TestPublicTradesPagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 5; i++ {
+		server.AddPublicTrades("BTCUSDT", models.PublicTrade{
+			ID:        int64(i),
+			Price:     decimal.MustParse("20000"),
+			Quantity:  decimal.MustParse("1"),
+			Side:      "buy",
+			Timestamp: models.NewTime(start.Add(time.Duration(i) * time.Minute)),
+		})
+	}
+	trades, err := server.Client().GetTradesOfSymbol(
+		context.Background(),
+		args.Symbol("BTCUSDT"),
+		args.Sort(args.SortASC),
+		args.From(start.Add(2*time.Minute)),
+		args.Limit(2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 2 || trades[0].ID != 2 || trades[1].ID != 3 {
+		t.Fatalf("unexpected trades %+v", trades)
+	}
+}
+
+func TestIterateTrades(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 5; i++ {
+		server.AddPublicTrades("BTCUSDT", models.PublicTrade{
+			ID:        int64(i),
+			Price:     decimal.MustParse("20000"),
+			Quantity:  decimal.MustParse("1"),
+			Side:      "buy",
+			Timestamp: models.NewTime(start.Add(time.Duration(i) * time.Minute)),
+		})
+	}
+	iterator := server.Client().IterateTradesOfSymbol(context.Background(), args.Symbol("BTCUSDT"), args.Limit(2))
+	var ids []int64
+	for iterator.Next() {
+		ids = append(ids, iterator.Value().ID)
+	}
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 5 || ids[0] != 5 || ids[4] != 1 {
+		t.Fatalf("unexpected trades %v", ids)
+	}
+}
+
+func TestRejectsBadCredentials(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := rest.NewClientWithOptions(rest.WithBaseURL(server.URL), rest.WithCredentials(APIKey, "wrong secret"))
+	if _, err := client.GetSpotTradingBalances(context.Background()); !errors.Is(err, models.ErrAuthFailed) {
+		t.Fatalf("expected auth failure, got %v", err)
+	}
+	server.AddAccount("other-key", "other-secret")
+	client = rest.NewClientWithOptions(rest.WithBaseURL(server.URL), rest.WithCredentials("other-key", "other-secret"))
+	if _, err := client.GetSpotTradingBalances(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHS256Message(t *testing.T) {
+	// signatures of the exchange layout, computed outside of the sdk
+	cases := []struct {
+		method, target, body, window, signature string
+	}{
+		{"GET", "/api/3/spot/balance?currency=USDT", "", "20000", "005a8d325e65ddc4b7d8191912bf3893694ebd9b3b92c017b0ac2171d34f4374"},
+		{"POST", "/api/3/spot/order", "symbol=EOSETH&side=sell", "", "bfff7e70913d854d57f6c479810ea6e27a62800744cc6b0b84f34ea32b22fa15"},
+	}
+	for _, c := range cases {
+		request := httptest.NewRequest(c.method, c.target, strings.NewReader(c.body))
+		if signature := hs256("secret", restMessage(request, []byte(c.body), "1650000000000", c.window)); signature != c.signature {
+			t.Errorf("unexpected signature %s of %s %s", signature, c.method, c.target)
+		}
+	}
+}
+
+func TestRejectsSkewedTimestamps(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetClockOffset(time.Minute)
+	client := server.Client(rest.WithWindow(1000))
+	if _, err := client.GetSpotTradingBalances(context.Background()); !errors.Is(err, models.ErrAuthFailed) {
+		t.Fatalf("expected auth failure, got %v", err)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetSpotBalance("USDT", "1000")
+	client := server.Client()
+	ctx := context.Background()
+	order, err := client.CreateSpotOrder(ctx,
+		args.ClientOrderID("resting"),
+		args.Symbol("BTCUSDT"),
+		args.Side(args.SideBuy),
+		args.Quantity("0.01"),
+		args.Price("19000"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != args.OrderStatusNew {
+		t.Fatalf("expected a new order, got %v", order.Status)
+	}
+	balance, err := client.GetSpotTradingBalanceOfCurrency(ctx, args.Currency("USDT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !balance.Available.Equal(decimal.MustParse("810")) || !balance.Reserved.Equal(decimal.MustParse("190")) {
+		t.Fatalf("unexpected balance %+v", balance)
+	}
+	if _, err := client.CancelSpotOrder(ctx, args.ClientOrderID("resting")); err != nil {
+		t.Fatal(err)
+	}
+	if orders := server.ActiveOrders(); len(orders) != 0 {
+		t.Fatalf("unexpected active orders %+v", orders)
+	}
+	order, err = client.CreateSpotOrder(ctx,
+		args.Symbol("BTCUSDT"),
+		args.Side(args.SideBuy),
+		args.Quantity("0.01"),
+		args.Price("20010"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != args.OrderStatusFilled {
+		t.Fatalf("expected a filled order, got %v", order.Status)
+	}
+	btc, err := client.GetSpotTradingBalanceOfCurrency(ctx, args.Currency("BTC"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !btc.Available.Equal(decimal.MustParse("0.01")) {
+		t.Fatalf("unexpected balance %+v", btc)
+	}
+	history, err := client.GetSpotOrdersHistory(ctx, args.Symbol("BTCUSDT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].ClientOrderID != order.ClientOrderID {
+		t.Fatalf("unexpected history %+v", history)
+	}
+}
+
+func TestInsufficientFunds(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	_, err := server.Client().CreateSpotOrder(context.Background(),
+		args.Symbol("BTCUSDT"),
+		args.Side(args.SideSell),
+		args.Quantity("1"),
+		args.Price("30000"),
+	)
+	if !errors.Is(err, models.ErrInsufficientFunds) {
+		t.Fatalf("expected insufficient funds, got %v", err)
+	}
+}
+
+func TestTransferAndTransactions(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetWalletBalance("USDT", "100")
+	client := server.Client()
+	ctx := context.Background()
+	id, err := client.TransferBetweenWalletAndExchange(ctx,
+		args.Currency("USDT"),
+		args.Amount("40.5"),
+		args.Source(args.AccountWallet),
+		args.Destination(args.AccountSpot),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wallet, err := client.GetWalletBalanceOfCurrency(ctx, args.Currency("USDT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !wallet.Available.Equal(decimal.MustParse("59.5")) {
+		t.Fatalf("unexpected balance %+v", wallet)
+	}
+	transaction, err := client.GetTransaction(ctx, args.ID(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !transaction.Native.Amount.Equal(decimal.MustParse("40.5")) || transaction.Type != args.TransactionTypeTransfer {
+		t.Fatalf("unexpected transaction %+v", transaction)
+	}
+}
+
+func TestSubAccounts(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetWalletBalance("BTC", "1")
+	server.AddSubAccount(models.SubAccount{ID: "sub1", Email: "sub1@example.com"})
+	client := server.Client()
+	ctx := context.Background()
+	if _, err := client.TransferFunds(ctx,
+		args.SubAccountID("sub1"),
+		args.Currency("BTC"),
+		args.Amount("0.25"),
+		args.TransferType(args.TransferToSubAccount),
+	); err != nil {
+		t.Fatal(err)
+	}
+	balances, err := client.GetSubAccountBalances(ctx, args.SubAccountID("sub1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances.Wallet) != 1 || !balances.Wallet[0].Available.Equal(decimal.MustParse("0.25")) {
+		t.Fatalf("unexpected balances %+v", balances)
+	}
+	if _, err := client.FreezeSubAccounts(ctx, args.SubAccountIDs("sub1")); err != nil {
+		t.Fatal(err)
+	}
+	accounts, err := client.GetSubAccounts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 1 || accounts[0].Status != "disable" {
+		t.Fatalf("unexpected sub-accounts %+v", accounts)
+	}
+}
@@ -0,0 +1,688 @@
+package cryptomkttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/cryptomkt/cryptomkt-go/v3/websocket"
+)
+
+const wsPrefix = "/api/3/ws/"
+
+// streams of the websocket api
+const (
+	streamPublic  = "public"
+	streamTrading = "trading"
+	streamWallet  = "wallet"
+)
+
+// private feeds of a session
+const (
+	feedReports        = "reports"
+	feedSpotBalances   = "spotBalances"
+	feedWalletBalances = "walletBalances"
+	feedTransactions   = "transactions"
+)
+
+const errCodeMethodNotFound = -32601
+
+var upgrader = gorilla.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+type wsRequest struct {
+	ID     int64           `json:"id"`
+	Method string          `json:"method"`
+	Ch     string          `json:"ch"`
+	Params json.RawMessage `json:"params"`
+}
+
+// wsSession is a websocket connection to the server
+type wsSession struct {
+	server        *Server
+	stream        string
+	conn          *gorilla.Conn
+	out           chan []byte
+	done          chan struct{}
+	closeOnce     *sync.Once
+	lock          *sync.Mutex
+	authenticated bool
+	stalled       bool
+	channels      map[string][]string
+	feeds         map[string]bool
+	pending       []interface{}
+}
+
+// multiResult is the result of a request answered with a response per item, as an order list
+type multiResult []interface{}
+
+type wsHandler func(session *wsSession, request *wsRequest, params map[string]string) (interface{}, *apiError)
+
+// Connector returns a websocket.Connector connecting the websocket clients to the server
+// instead of the exchange, keeping the path of the requested url. Implements websocket.ContextConnector
+func (server *Server) Connector() websocket.Connector {
+	return serverConnector{host: strings.TrimPrefix(server.URL, "http://")}
+}
+
+type serverConnector struct {
+	host string
+}
+
+func (connector serverConnector) Connect(rawURL string) (websocket.Conn, *http.Response, error) {
+	return connector.ConnectContext(context.Background(), rawURL)
+}
+
+func (connector serverConnector) ConnectContext(ctx context.Context, rawURL string) (websocket.Conn, *http.Response, error) {
+	path := wsPrefix
+	if parsed, err := url.Parse(rawURL); err == nil {
+		path = parsed.Path
+	}
+	conn, response, err := gorilla.DefaultDialer.DialContext(ctx, "ws://"+connector.host+path, nil)
+	if err != nil {
+		return nil, response, err
+	}
+	return conn, response, nil
+}
+
+// MarketDataClient returns a market data client connected to the server,
+// with the given options applied after the ones targeting the server
+func (server *Server) MarketDataClient(options ...websocket.ClientOption) (*websocket.MarketDataClient, error) {
+	return websocket.NewMarketDataClient(server.wsOptions(options)...)
+}
+
+// SpotTradingClient returns a spot trading client connected to the server and logged in
+// with the APIKey and APISecret credentials
+func (server *Server) SpotTradingClient(options ...websocket.ClientOption) (*websocket.SpotTradingClient, error) {
+	return websocket.NewSpotTradingClient(APIKey, APISecret, 0, server.wsOptions(options)...)
+}
+
+// WalletManagementClient returns a wallet management client connected to the server and
+// logged in with the APIKey and APISecret credentials
+func (server *Server) WalletManagementClient(options ...websocket.ClientOption) (*websocket.WalletManagementClient, error) {
+	return websocket.NewWalletManagementClient(APIKey, APISecret, 0, server.wsOptions(options)...)
+}
+
+func (server *Server) wsOptions(options []websocket.ClientOption) []websocket.ClientOption {
+	return append([]websocket.ClientOption{websocket.WithConnector(server.Connector())}, options...)
+}
+
+// Publish sends a notification of a public channel, as "trades" or "orderbook/full", to
+// every connection subscribed to it. data is sent as given, under the snapshot, update or
+// data key of the notification type. Returns the number of connections notified
+func (server *Server) Publish(ch string, notificationType args.NotificationType, data interface{}) int {
+	frame := map[string]interface{}{"ch": ch, string(notificationType): data}
+	sent := 0
+	for _, session := range server.wsSessions() {
+		if session.subscribed(ch) {
+			session.send(frame)
+			sent++
+		}
+	}
+	return sent
+}
+
+// SendFrame sends a raw frame to every websocket connection, to script frames the
+// server would not send by itself
+func (server *Server) SendFrame(data []byte) {
+	for _, session := range server.wsSessions() {
+		session.sendRaw(data)
+	}
+}
+
+// FailNext makes the next websocket request of the method fail with the error.
+// Failures of a method are used in the order they were given
+func (server *Server) FailNext(method string, err models.APIError) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.failures[method] = append(server.failures[method], err)
+}
+
+// Disconnect drops every websocket connection without a close frame, as a network
+// failure would. Returns the number of connections dropped. A connection is registered
+// by the server right after its handshake, so make a round trip on a new connection first
+func (server *Server) Disconnect() int {
+	sessions := server.wsSessions()
+	for _, session := range sessions {
+		session.close()
+	}
+	return len(sessions)
+}
+
+// CloseWebsockets closes every websocket connection with a going away close frame, as the
+// exchange does on maintenance. Returns the number of connections closed
+func (server *Server) CloseWebsockets() int {
+	sessions := server.wsSessions()
+	frame := gorilla.FormatCloseMessage(gorilla.CloseGoingAway, "maintenance")
+	for _, session := range sessions {
+		session.conn.WriteControl(gorilla.CloseMessage, frame, time.Now().Add(time.Second))
+		session.close()
+	}
+	return len(sessions)
+}
+
+// Stall makes every open websocket connection stop answering, pings included, while staying
+// open, as a half-open connection would. Returns the number of connections stalled. New
+// connections are not stalled
+func (server *Server) Stall() int {
+	sessions := server.wsSessions()
+	for _, session := range sessions {
+		session.lock.Lock()
+		session.stalled = true
+		session.lock.Unlock()
+	}
+	return len(sessions)
+}
+
+// WebsocketConnections returns the number of open websocket connections
+func (server *Server) WebsocketConnections() int {
+	return len(server.wsSessions())
+}
+
+func (server *Server) wsSessions() []*wsSession {
+	server.lock.RLock()
+	defer server.lock.RUnlock()
+	sessions := make([]*wsSession, 0, len(server.sessions))
+	for session := range server.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+func (server *Server) nextFailure(method string) *apiError {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	failures := server.failures[method]
+	if len(failures) == 0 {
+		return nil
+	}
+	server.failures[method] = failures[1:]
+	return &apiError{status: http.StatusBadRequest, APIError: failures[0]}
+}
+
+// broadcast sends the changes of the account to the sessions subscribed to them
+func (server *Server) broadcast(change event) {
+	for _, session := range server.wsSessions() {
+		if change.report != nil && session.hasFeed(feedReports) {
+			session.send(notificationOf("spot_order", change.report))
+		}
+		if change.spotBalances != nil && session.hasFeed(feedSpotBalances) {
+			session.send(notificationOf("spot_balance", change.spotBalances))
+		}
+		if change.walletBalance != nil && session.hasFeed(feedWalletBalances) {
+			session.send(notificationOf("wallet_balance_update", change.walletBalance))
+		}
+		if change.transaction != nil && session.hasFeed(feedTransactions) {
+			session.send(notificationOf("transaction_update", change.transaction))
+		}
+	}
+}
+
+func notificationOf(method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+}
+
+func (server *Server) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	stream := strings.TrimPrefix(r.URL.Path, wsPrefix)
+	if stream != streamPublic && stream != streamTrading && stream != streamWallet {
+		writeError(w, errNotFound(r.URL.Path))
+		return
+	}
+	header := http.Header{"Date": {server.now().UTC().Format(http.TimeFormat)}}
+	conn, err := upgrader.Upgrade(w, r, header)
+	if err != nil {
+		return
+	}
+	session := &wsSession{
+		server:    server,
+		stream:    stream,
+		conn:      conn,
+		out:       make(chan []byte, 256),
+		done:      make(chan struct{}),
+		closeOnce: new(sync.Once),
+		lock:      new(sync.Mutex),
+		channels:  make(map[string][]string),
+		feeds:     make(map[string]bool),
+	}
+	conn.SetPingHandler(func(data string) error {
+		if session.isStalled() {
+			return nil
+		}
+		return conn.WriteControl(gorilla.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	server.lock.Lock()
+	server.sessions[session] = true
+	server.lock.Unlock()
+	go session.writeLoop()
+	session.readLoop()
+}
+
+func (session *wsSession) writeLoop() {
+	for {
+		select {
+		case <-session.done:
+			return
+		case data := <-session.out:
+			if err := session.conn.WriteMessage(gorilla.TextMessage, data); err != nil {
+				session.close()
+				return
+			}
+		}
+	}
+}
+
+func (session *wsSession) readLoop() {
+	defer session.close()
+	for {
+		_, data, err := session.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var request wsRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			session.respondError(0, errValidation("malformed frame"))
+			continue
+		}
+		session.handle(&request)
+	}
+}
+
+func (session *wsSession) close() {
+	session.closeOnce.Do(func() {
+		close(session.done)
+		session.conn.Close()
+		session.server.lock.Lock()
+		delete(session.server.sessions, session)
+		session.server.lock.Unlock()
+	})
+}
+
+func (session *wsSession) send(frame interface{}) {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return
+	}
+	session.sendRaw(data)
+}
+
+func (session *wsSession) sendRaw(data []byte) {
+	if session.isStalled() {
+		return
+	}
+	select {
+	case session.out <- data:
+	case <-session.done:
+	}
+}
+
+func (session *wsSession) respondError(id int64, apiError *apiError) {
+	session.send(map[string]interface{}{"jsonrpc": "2.0", "error": apiError.APIError, "id": id})
+}
+
+// later queues a frame to send after the response of the request in process
+func (session *wsSession) later(frame interface{}) {
+	session.pending = append(session.pending, frame)
+}
+
+func (session *wsSession) isStalled() bool {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	return session.stalled
+}
+
+func (session *wsSession) subscribed(ch string) bool {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	_, ok := session.channels[ch]
+	return ok
+}
+
+func (session *wsSession) hasFeed(feed string) bool {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	return session.feeds[feed]
+}
+
+func (session *wsSession) setFeed(feed string, on bool) {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	session.feeds[feed] = on
+}
+
+func (session *wsSession) handle(request *wsRequest) {
+	session.pending = nil
+	params, apiError := wsParamsOf(request.Params)
+	if apiError == nil {
+		apiError = session.server.nextFailure(request.Method)
+	}
+	var result interface{}
+	if apiError == nil {
+		result, apiError = session.dispatch(request, params)
+	}
+	if apiError != nil {
+		session.respondError(request.ID, apiError)
+		return
+	}
+	results, ok := result.(multiResult)
+	if !ok {
+		results = multiResult{result}
+	}
+	for _, result := range results {
+		session.send(map[string]interface{}{"jsonrpc": "2.0", "result": result, "id": request.ID})
+	}
+	for _, frame := range session.pending {
+		session.send(frame)
+	}
+}
+
+func (session *wsSession) dispatch(request *wsRequest, params map[string]string) (interface{}, *apiError) {
+	if session.stream == streamPublic {
+		handler, ok := publicMethods[request.Method]
+		if !ok {
+			return nil, errMethodNotFound(request.Method)
+		}
+		return handler(session, request, params)
+	}
+	if request.Method == "login" {
+		var login loginParams
+		json.Unmarshal(request.Params, &login)
+		if apiError := session.server.login(login); apiError != nil {
+			return nil, apiError
+		}
+		session.lock.Lock()
+		session.authenticated = true
+		session.lock.Unlock()
+		return true, nil
+	}
+	methods := tradingMethods
+	if session.stream == streamWallet {
+		methods = walletMethods
+	}
+	handler, ok := methods[request.Method]
+	if !ok {
+		return nil, errMethodNotFound(request.Method)
+	}
+	session.lock.Lock()
+	authenticated := session.authenticated
+	session.lock.Unlock()
+	if !authenticated {
+		return nil, errAuthorization(models.ErrorCodeAuthorizationRequired, "login first")
+	}
+	return handler(session, request, params)
+}
+
+func errMethodNotFound(method string) *apiError {
+	return newAPIError(http.StatusBadRequest, errCodeMethodNotFound, "Method not found", "no method "+method)
+}
+
+// wsParamsOf returns the params of a frame with lists joined by commas
+func wsParamsOf(raw json.RawMessage) (map[string]string, *apiError) {
+	params := make(map[string]string)
+	if len(bytes.TrimSpace(raw)) == 0 || string(raw) == "null" {
+		return params, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	values := make(map[string]interface{})
+	if err := decoder.Decode(&values); err != nil {
+		return nil, errValidation("malformed params")
+	}
+	for key, value := range values {
+		params[key] = stringOf(value)
+	}
+	return params, nil
+}
+
+// fromREST adapts the handler of a rest route, taking the variables of its path from the params
+func fromREST(handler handler, vars ...string) wsHandler {
+	return func(session *wsSession, request *wsRequest, params map[string]string) (interface{}, *apiError) {
+		restRequest := &restRequest{params: params, vars: make(map[string]string)}
+		for _, name := range vars {
+			restRequest.vars[name] = params[name]
+		}
+		return handler(session.server, restRequest)
+	}
+}
+
+// asReport converts the order result of a handler to a report
+func asReport(handler wsHandler) wsHandler {
+	return func(session *wsSession, request *wsRequest, params map[string]string) (interface{}, *apiError) {
+		result, apiError := handler(session, request, params)
+		if apiError != nil {
+			return nil, apiError
+		}
+		switch order := result.(type) {
+		case *models.Order:
+			return reportOf(order, reportTypeOf(order)), nil
+		case []models.Order:
+			reports := make([]models.Report, len(order))
+			for i := range order {
+				reports[i] = reportOf(&order[i], args.ReportStatus)
+			}
+			return reports, nil
+		}
+		return result, nil
+	}
+}
+
+func reportTypeOf(order *models.Order) args.ReportType {
+	switch order.Status {
+	case args.OrderStatusFilled, args.OrderStatusPartiallyFilled:
+		return args.ReportTrade
+	case args.OrderStatusCanceled:
+		return args.ReportCanceled
+	case args.OrderStatusExpired:
+		return args.ReportExpired
+	}
+	if order.OriginalClientOrderID != "" {
+		return args.ReportReplaced
+	}
+	return args.ReportNew
+}
+
+// subscribeTo returns a handler turning a private feed on, sending the snapshot of the feed if any
+func subscribeTo(feed string, snapshot func(*exchange) interface{}) wsHandler {
+	return func(session *wsSession, request *wsRequest, params map[string]string) (interface{}, *apiError) {
+		session.setFeed(feed, true)
+		if snapshot != nil {
+			session.server.exchange.lock.Lock()
+			session.later(snapshot(session.server.exchange))
+			session.server.exchange.lock.Unlock()
+		}
+		return true, nil
+	}
+}
+
+func unsubscribeFrom(feed string) wsHandler {
+	return func(session *wsSession, request *wsRequest, params map[string]string) (interface{}, *apiError) {
+		session.setFeed(feed, false)
+		return true, nil
+	}
+}
+
+func createOrderList(session *wsSession, request *wsRequest, params map[string]string) (interface{}, *apiError) {
+	var list struct {
+		OrderListID string            `json:"order_list_id"`
+		Orders      []json.RawMessage `json:"orders"`
+	}
+	json.Unmarshal(request.Params, &list)
+	reports := make(multiResult, 0, len(list.Orders))
+	for _, raw := range list.Orders {
+		orderParams, apiError := wsParamsOf(raw)
+		if apiError != nil {
+			return nil, apiError
+		}
+		order, apiError := session.server.exchange.createOrder(orderRequestOf(orderParams))
+		if apiError != nil {
+			return nil, apiError
+		}
+		report := reportOf(order, reportTypeOf(order))
+		report.OrderListID = list.OrderListID
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+var tradingMethods = map[string]wsHandler{
+	"spot_balances":       fromREST(getSpotBalances),
+	"spot_balance":        fromREST(getSpotBalance, "currency"),
+	"spot_get_orders":     asReport(fromREST(getActiveOrders)),
+	"spot_new_order":      asReport(fromREST(createOrder)),
+	"spot_new_order_list": createOrderList,
+	"spot_cancel_order":   asReport(fromREST(cancelOrder, "client_order_id")),
+	"spot_cancel_orders":  fromREST(cancelOrders),
+	"spot_replace_order":  asReport(fromREST(replaceOrder, "client_order_id")),
+	"spot_fees":           fromREST(getTradingCommissions),
+	"spot_fee":            fromREST(getTradingCommission, "symbol"),
+	"spot_subscribe": subscribeTo(feedReports, func(exchange *exchange) interface{} {
+		reports := make([]models.Report, len(exchange.activeOrders))
+		for i, order := range exchange.activeOrders {
+			reports[i] = reportOf(order, args.ReportStatus)
+		}
+		return notificationOf("spot_orders", reports)
+	}),
+	"spot_unsubscribe": unsubscribeFrom(feedReports),
+	"spot_balance_subscribe": subscribeTo(feedSpotBalances, func(exchange *exchange) interface{} {
+		return notificationOf("spot_balance", sortedBalances(exchange.spotBalances))
+	}),
+	"spot_balance_unsubscribe": unsubscribeFrom(feedSpotBalances),
+}
+
+var walletMethods = map[string]wsHandler{
+	"wallet_balances":  fromREST(getWalletBalances),
+	"wallet_balance":   fromREST(getWalletBalance, "currency"),
+	"get_transactions": fromREST(getTransactions),
+	"subscribe_wallet_balances": subscribeTo(feedWalletBalances, func(exchange *exchange) interface{} {
+		return notificationOf("wallet_balances", sortedBalances(exchange.walletBalances))
+	}),
+	"unsubscribe_wallet_balances": unsubscribeFrom(feedWalletBalances),
+	"subscribe_transactions":      subscribeTo(feedTransactions, nil),
+	"unsubscribe_transactions":    unsubscribeFrom(feedTransactions),
+}
+
+var publicMethods = map[string]wsHandler{
+	"subscribe":     subscribeToChannel,
+	"unsubscribe":   unsubscribeFromChannel,
+	"subscriptions": getSubscriptions,
+}
+
+type channelResult struct {
+	Ch            string   `json:"ch"`
+	Subscriptions []string `json:"subscriptions"`
+}
+
+// symbolsOf returns the symbols of the params, all the symbols of the exchange for "*"
+func (session *wsSession) symbolsOf(params map[string]string) []string {
+	symbols := listOf(params["symbols"])
+	if !contains(symbols, "*") {
+		return symbols
+	}
+	session.server.exchange.lock.Lock()
+	defer session.server.exchange.lock.Unlock()
+	symbols = make([]string, 0, len(session.server.exchange.symbols))
+	for symbol := range session.server.exchange.symbols {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func subscribeToChannel(session *wsSession, request *wsRequest, params map[string]string) (interface{}, *apiError) {
+	if request.Ch == "" {
+		return nil, errValidation("missing ch")
+	}
+	symbols := session.symbolsOf(params)
+	session.server.exchange.lock.Lock()
+	for _, symbol := range symbols {
+		if _, ok := session.server.exchange.symbols[symbol]; !ok {
+			session.server.exchange.lock.Unlock()
+			return nil, errSymbolNotFound(symbol)
+		}
+	}
+	if snapshot := snapshotOf(session.server.exchange, request.Ch, symbols); snapshot != nil {
+		session.later(map[string]interface{}{"ch": request.Ch, "snapshot": snapshot})
+	}
+	session.server.exchange.lock.Unlock()
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	subscribed := session.channels[request.Ch]
+	for _, symbol := range symbols {
+		if !contains(subscribed, symbol) {
+			subscribed = append(subscribed, symbol)
+		}
+	}
+	session.channels[request.Ch] = subscribed
+	return channelResult{Ch: request.Ch, Subscriptions: subscribed}, nil
+}
+
+// snapshotOf returns the snapshot sent on subscription to the full order book and to
+// the trades, nil for the channels fed only by Publish
+func snapshotOf(exchange *exchange, ch string, symbols []string) interface{} {
+	switch ch {
+	case "orderbook/full":
+		feed := make(models.WSOrderbookFeed)
+		for _, symbol := range symbols {
+			book := bookJSON(exchange.orderBooks[symbol], 0)
+			feed[symbol] = models.WSOrderbook{
+				Timestamp:      models.NewTimeFromMillis(exchange.now().UnixMilli()),
+				SequenceNumber: 1,
+				Ask:            book.Ask,
+				Bid:            book.Bid,
+			}
+		}
+		return feed
+	case "trades":
+		feed := make(models.WSTradeFeed)
+		for _, symbol := range symbols {
+			trades := make([]models.WSTrade, 0)
+			for _, trade := range exchange.publicTrades[symbol] {
+				trades = append(trades, models.WSTrade{
+					Timestamp: models.NewTimeFromMillis(trade.Timestamp.Millis()),
+					ID:        trade.ID,
+					Price:     trade.Price,
+					Quantity:  trade.Quantity,
+					Side:      trade.Side,
+				})
+			}
+			feed[symbol] = trades
+		}
+		return feed
+	}
+	return nil
+}
+
+func unsubscribeFromChannel(session *wsSession, request *wsRequest, params map[string]string) (interface{}, *apiError) {
+	symbols := session.symbolsOf(params)
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	remaining := make([]string, 0)
+	for _, symbol := range session.channels[request.Ch] {
+		if len(symbols) > 0 && !contains(symbols, symbol) {
+			remaining = append(remaining, symbol)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(session.channels, request.Ch)
+	} else {
+		session.channels[request.Ch] = remaining
+	}
+	return channelResult{Ch: request.Ch, Subscriptions: remaining}, nil
+}
+
+func getSubscriptions(session *wsSession, request *wsRequest, params map[string]string) (interface{}, *apiError) {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	subscriptions := session.channels[request.Ch]
+	if subscriptions == nil {
+		subscriptions = []string{}
+	}
+	return channelResult{Ch: request.Ch, Subscriptions: subscriptions}, nil
+}
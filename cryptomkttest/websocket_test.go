@@ -0,0 +1,517 @@
+package cryptomkttest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/cryptomkt/cryptomkt-go/v3/websocket"
+)
+
+func receive[T any](t *testing.T, ch chan T) T {
+	t.Helper()
+	select {
+	case value, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed")
+		}
+		return value
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+	var zero T
+	return zero
+}
+
+func TestMarketDataFeeds(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.MarketDataClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	subscription, err := client.SubscribeToFullOrderbook(args.Symbols([]string{"BTCUSDT"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subscription.Symbols) != 1 || subscription.Symbols[0] != "BTCUSDT" {
+		t.Fatalf("unexpected symbols %v", subscription.Symbols)
+	}
+	snapshot := receive(t, subscription.NotificationCh)
+	if snapshot.NotificationType != args.NotificationSnapshot || !snapshot.Data["BTCUSDT"].Bid[0][0].Equal(decimal.MustParse("20000")) {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+	sent := server.Publish("orderbook/full", args.NotificationUpdate, models.WSOrderbookFeed{
+		"BTCUSDT": {SequenceNumber: 2, Bid: [][]decimal.Decimal{{decimal.MustParse("20001"), decimal.NewFromInt(1)}}},
+	})
+	if sent != 1 {
+		t.Fatalf("expected one subscriber, got %d", sent)
+	}
+	update := receive(t, subscription.NotificationCh)
+	if update.NotificationType != args.NotificationUpdate || update.Data["BTCUSDT"].SequenceNumber != 2 {
+		t.Fatalf("unexpected update %+v", update)
+	}
+	symbols, err := client.GetActiveSubscriptions(context.Background(), args.Subscription(args.SubscriptionTypeFullOrderbook("")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(symbols) != 1 {
+		t.Fatalf("unexpected subscriptions %v", symbols)
+	}
+}
+
+func TestTradingReports(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetSpotBalance("USDT", "1000")
+	client, err := server.SpotTradingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	reports, err := client.SubscribeToReports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot := receive(t, reports); snapshot.NotificationType != args.NotificationSnapshot || len(snapshot.Data) != 0 {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+	report, err := client.CreateSpotOrder(context.Background(),
+		args.ClientOrderID("ws-order"),
+		args.Symbol("BTCUSDT"),
+		args.Side(args.SideBuy),
+		args.Quantity("0.01"),
+		args.Price("19000"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.ReportType != args.ReportNew {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	update := receive(t, reports)
+	if update.NotificationType != args.NotificationUpdate || update.Data[0].ClientOrderID != "ws-order" {
+		t.Fatalf("unexpected update %+v", update)
+	}
+	// orders of the rest api are reported too
+	if _, err := server.Client().CancelSpotOrder(context.Background(), args.ClientOrderID("ws-order")); err != nil {
+		t.Fatal(err)
+	}
+	update = receive(t, reports)
+	if update.Data[0].ReportType != args.ReportCanceled {
+		t.Fatalf("unexpected update %+v", update)
+	}
+}
+
+func TestWalletBalanceUpdates(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetWalletBalance("BTC", "1")
+	client, err := server.WalletManagementClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	balances, err := client.SubscribeToWalletBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot := receive(t, balances); snapshot.NotificationType != args.NotificationSnapshot || len(snapshot.Data) != 1 {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+	server.SetWalletBalance("BTC", "2")
+	update := receive(t, balances)
+	if update.NotificationType != args.NotificationUpdate || !update.Data[0].Available.Equal(decimal.MustParse("2")) {
+		t.Fatalf("unexpected update %+v", update)
+	}
+}
+
+func TestInjectedErrors(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.SpotTradingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	server.FailNext("spot_balances", models.APIError{Code: models.ErrorCodeTooManyRequests, Message: "Too many requests"})
+	if _, err := client.GetSpotTradingBalances(context.Background()); !errors.Is(err, models.ErrRateLimited) {
+		t.Fatalf("expected rate limited, got %v", err)
+	}
+	if _, err := client.GetSpotTradingBalances(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebsocketLoginFailure(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	_, err := websocket.NewSpotTradingClient(APIKey, "wrong secret", 0, websocket.WithConnector(server.Connector()))
+	if !errors.Is(err, models.ErrAuthFailed) {
+		t.Fatalf("expected auth failure, got %v", err)
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.MarketDataClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	// a round trip makes sure the server registered the connection
+	if _, err := client.GetActiveSubscriptions(context.Background(), args.Subscription(args.SubscriptionTypeTrades())); err != nil {
+		t.Fatal(err)
+	}
+	if dropped := server.Disconnect(); dropped != 1 {
+		t.Fatalf("expected one connection, got %d", dropped)
+	}
+	if connections := server.WebsocketConnections(); connections != 0 {
+		t.Fatalf("expected no connections, got %d", connections)
+	}
+}
+
+func TestReconnection(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetSpotBalance("USDT", "1000")
+	policy := websocket.ReconnectPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	marketData, err := server.MarketDataClient(websocket.WithReconnectPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer marketData.Close()
+	trading, err := server.SpotTradingClient(websocket.WithReconnectPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer trading.Close()
+	subscription, err := marketData.SubscribeToFullOrderbook(args.Symbols([]string{"BTCUSDT"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive(t, subscription.NotificationCh)
+	reports, err := trading.SubscribeToReports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive(t, reports)
+	if dropped := server.Disconnect(); dropped != 2 {
+		t.Fatalf("expected two connections, got %d", dropped)
+	}
+	if resynced := receive(t, subscription.NotificationCh); resynced.NotificationType != args.NotificationResynced {
+		t.Fatalf("expected a resynced notification, got %+v", resynced)
+	}
+	if snapshot := receive(t, subscription.NotificationCh); snapshot.NotificationType != args.NotificationSnapshot {
+		t.Fatalf("expected a fresh snapshot, got %+v", snapshot)
+	}
+	if resynced := receive(t, reports); resynced.NotificationType != args.NotificationResynced {
+		t.Fatalf("expected a resynced notification, got %+v", resynced)
+	}
+	if snapshot := receive(t, reports); snapshot.NotificationType != args.NotificationSnapshot {
+		t.Fatalf("expected a fresh snapshot, got %+v", snapshot)
+	}
+	// the trading client is logged in again
+	if _, err := trading.CreateSpotOrder(context.Background(), args.Symbol("BTCUSDT"), args.Side(args.SideBuy), args.Quantity("0.01"), args.Price("19000")); err != nil {
+		t.Fatal(err)
+	}
+	if update := receive(t, reports); update.NotificationType != args.NotificationUpdate {
+		t.Fatalf("unexpected update %+v", update)
+	}
+}
+
+func TestReconnectionWithoutDelay(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.SpotTradingClient(websocket.WithReconnectPolicy(websocket.ReconnectPolicy{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	events := client.Events()
+	expectEvents(t, events, websocket.EventConnected, websocket.EventLoggedIn)
+	reports, err := client.SubscribeToReports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// unread reports hold the handling of the client, so the login of the resync is
+	// answered while the loss of the connection is still being handled
+	server.SendFrame([]byte(`{"jsonrpc":"2.0","method":"spot_order","params":{"id":1}}`))
+	server.SendFrame([]byte(`{"jsonrpc":"2.0","method":"spot_order","params":{"id":2}}`))
+	time.Sleep(50 * time.Millisecond)
+	server.Disconnect()
+	time.Sleep(50 * time.Millisecond)
+	for _, notificationType := range []args.NotificationType{args.NotificationSnapshot, args.NotificationUpdate, args.NotificationUpdate, args.NotificationResynced, args.NotificationSnapshot} {
+		if notification := receive(t, reports); notification.NotificationType != notificationType {
+			t.Fatalf("expected a %s notification, got %+v", notificationType, notification)
+		}
+	}
+	expectEvents(t, events,
+		websocket.EventDisconnected,
+		websocket.EventReconnecting,
+		websocket.EventConnected,
+		websocket.EventLoggedIn,
+		websocket.EventReconnected,
+	)
+}
+
+func TestReconnectionGivesUp(t *testing.T) {
+	server := NewServer()
+	client, err := server.MarketDataClient(websocket.WithReconnectPolicy(websocket.ReconnectPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	subscription, err := client.SubscribeToTrades(args.Symbols([]string{"BTCUSDT"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive(t, subscription.NotificationCh)
+	server.Close()
+	select {
+	case _, ok := <-subscription.NotificationCh:
+		if ok {
+			t.Fatal("the subscription should be closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+// staleErrors collects the stale connection errors of a client
+func staleErrors() (chan error, websocket.ClientOption) {
+	errs := make(chan error, 16)
+	return errs, websocket.WithErrorHandler(func(err error) {
+		if errors.Is(err, models.ErrStaleConnection) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}
+	})
+}
+
+func TestHeartbeat(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	errs, handler := staleErrors()
+	client, err := server.MarketDataClient(
+		websocket.WithHeartbeat(20*time.Millisecond, 20*time.Millisecond),
+		websocket.WithReconnectPolicy(websocket.ReconnectPolicy{BaseDelay: time.Millisecond}),
+		handler,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	subscription, err := client.SubscribeToTrades(args.Symbols([]string{"BTCUSDT"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive(t, subscription.NotificationCh)
+	// pongs keep an idle connection open
+	time.Sleep(100 * time.Millisecond)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected stale connection %v", <-errs)
+	}
+	server.Stall()
+	receive(t, errs)
+	if resynced := receive(t, subscription.NotificationCh); resynced.NotificationType != args.NotificationResynced {
+		t.Fatalf("expected a resynced notification, got %+v", resynced)
+	}
+}
+
+func TestSubscriptionTimeout(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	errs, handler := staleErrors()
+	client, err := server.MarketDataClient(websocket.WithSubscriptionTimeout(50*time.Millisecond), handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	subscription, err := client.SubscribeToTicker(args.Symbols([]string{"BTCUSDT"}), args.TickerSpeed(args.TickerSpeed1s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// without a reconnect policy the stale connection closes the client
+	if err := receive(t, errs); !strings.Contains(err.Error(), "ticker/1s") {
+		t.Fatalf("expected a stale ticker, got %v", err)
+	}
+	select {
+	case _, ok := <-subscription.NotificationCh:
+		if ok {
+			t.Fatal("the subscription should be closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+// expectEvents receives the events of the types in order
+func expectEvents(t *testing.T, events <-chan websocket.Event, types ...websocket.EventType) []websocket.Event {
+	t.Helper()
+	received := make([]websocket.Event, 0, len(types))
+	for _, eventType := range types {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("events closed, expected %s", eventType)
+			}
+			if event.Type != eventType || event.Time.IsZero() {
+				t.Fatalf("expected a %s event, got %+v", eventType, event)
+			}
+			received = append(received, event)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for a %s event", eventType)
+		}
+	}
+	return received
+}
+
+func TestLifecycleEvents(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.SpotTradingClient(websocket.WithReconnectPolicy(websocket.ReconnectPolicy{BaseDelay: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := client.Events()
+	expectEvents(t, events, websocket.EventConnected, websocket.EventLoggedIn)
+	if closed := server.CloseWebsockets(); closed != 1 {
+		t.Fatalf("expected one connection, got %d", closed)
+	}
+	received := expectEvents(t, events,
+		websocket.EventServerClosed,
+		websocket.EventReconnecting,
+		websocket.EventConnected,
+		websocket.EventLoggedIn,
+		websocket.EventReconnected,
+	)
+	if received[0].Err == nil || received[1].Attempt != 1 {
+		t.Fatalf("unexpected events %+v", received)
+	}
+	client.Close()
+	if closed := expectEvents(t, events, websocket.EventClosed); closed[0].Err != nil {
+		t.Fatalf("unexpected cause %v", closed[0].Err)
+	}
+	if _, ok := <-events; ok {
+		t.Fatal("events should be closed")
+	}
+}
+
+func TestLifecycleEventsWithoutReconnection(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.MarketDataClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	events := client.Events()
+	expectEvents(t, events, websocket.EventConnected)
+	if _, err := client.GetActiveSubscriptions(context.Background(), args.Subscription(args.SubscriptionTypeTrades())); err != nil {
+		t.Fatal(err)
+	}
+	server.Disconnect()
+	received := expectEvents(t, events, websocket.EventDisconnected, websocket.EventClosed)
+	var transportErr *models.TransportError
+	if !errors.As(received[1].Err, &transportErr) {
+		t.Fatalf("expected the cause of the disconnection, got %v", received[1].Err)
+	}
+}
+
+func TestTradingClientWithOptions(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := websocket.NewSpotTradingClientWithOptions(
+		websocket.WithBaseURL("ws"+strings.TrimPrefix(server.URL, "http")),
+		websocket.WithCredentials(APIKey, APISecret),
+		websocket.WithWindow(20_000),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if _, err := client.GetSpotTradingBalances(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubscriptionContext(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.MarketDataClient(websocket.WithReconnectPolicy(websocket.ReconnectPolicy{BaseDelay: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	events := client.Events()
+	expectEvents(t, events, websocket.EventConnected)
+	if _, err := client.GetActiveSubscriptions(context.Background(), args.Subscription(args.SubscriptionTypeTrades())); err != nil {
+		t.Fatal(err)
+	}
+	server.Stall()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.SubscribeToFullOrderbookContext(ctx, args.Symbols([]string{"BTCUSDT"})); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline of the context, got %v", err)
+	}
+	// the cancelled subscription is not requested again on reconnection
+	server.Disconnect()
+	expectEvents(t, events, websocket.EventDisconnected, websocket.EventReconnecting, websocket.EventConnected, websocket.EventReconnected)
+	symbols, err := client.GetActiveSubscriptions(context.Background(), args.Subscription(args.SubscriptionTypeFullOrderbook("")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(symbols) != 0 {
+		t.Fatalf("unexpected subscriptions %v", symbols)
+	}
+	subscription, err := client.SubscribeToFullOrderbookContext(context.Background(), args.Symbols([]string{"BTCUSDT"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot := receive(t, subscription.NotificationCh); snapshot.NotificationType != args.NotificationSnapshot {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+}
+
+// silentConn drops every message sent, as a server never answering
+type silentConn struct {
+	websocket.Conn
+}
+
+func (conn silentConn) WriteMessage(messageType int, data []byte) error {
+	return nil
+}
+
+func TestConstructorContext(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := websocket.NewMarketDataClientContext(ctx, websocket.WithConnector(server.Connector())); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancellation of the context, got %v", err)
+	}
+	silent := websocket.ConnectorFunc(func(url string) (websocket.Conn, *http.Response, error) {
+		conn, response, err := server.Connector().Connect(url)
+		if err != nil {
+			return nil, response, err
+		}
+		return silentConn{conn}, response, nil
+	})
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := websocket.NewSpotTradingClientContext(ctx, websocket.WithConnector(silent), websocket.WithCredentials(APIKey, APISecret))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline of the context, got %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+// Package decimal implements exact decimal numbers for the prices, quantities and amounts of the exchange.
+//
+// A Decimal keeps the digits after the point it was parsed with, so the strings of the exchange
+// round trip unchanged:
+//
+//	price := decimal.MustParse("0.046010")
+//	price.String() // "0.046010"
+//	total := price.Mul(decimal.MustParse("2.5")) // 0.11502500
+package decimal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number, as a coefficient and a number of digits after the point.
+// The zero value is 0
+type Decimal struct {
+	coefficient *big.Int
+	scale       int32
+}
+
+// Zero is the decimal 0
+var Zero = Decimal{}
+
+// New returns the decimal coefficient * 10^-scale, as New(12345, 2) for 123.45
+func New(coefficient int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coefficient: new(big.Int).Mul(big.NewInt(coefficient), pow10(-scale))}
+	}
+	return Decimal{coefficient: big.NewInt(coefficient), scale: scale}
+}
+
+// NewFromInt returns the decimal of an integer
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// maxExponent bounds the exponent of parsed decimals, as larger ones would build numbers of
+// unbounded size, far beyond any value of the exchange
+const maxExponent = 1000
+
+// Parse parses a decimal as "-123.450" or "1.5e-8". Exponents are bounded to ±1000
+func Parse(value string) (Decimal, error) {
+	invalid := func() (Decimal, error) {
+		return Decimal{}, fmt.Errorf("CryptomarketSDKError: invalid decimal %q", value)
+	}
+	mantissa, exponent := value, int64(0)
+	if index := strings.IndexAny(value, "eE"); index >= 0 {
+		parsed, err := strconv.ParseInt(value[index+1:], 10, 32)
+		if err != nil {
+			return invalid()
+		}
+		if parsed > maxExponent || parsed < -maxExponent {
+			return Decimal{}, fmt.Errorf("CryptomarketSDKError: exponent of decimal %q out of range", value)
+		}
+		mantissa, exponent = value[:index], parsed
+	}
+	digits := mantissa
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		digits = digits[1:]
+	}
+	integer, fraction, _ := strings.Cut(digits, ".")
+	if integer == "" && fraction == "" || !isDigits(integer) || !isDigits(fraction) {
+		return invalid()
+	}
+	coefficient, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return invalid()
+	}
+	if mantissa[0] == '-' {
+		coefficient.Neg(coefficient)
+	}
+	scale, ok := scaleOf(len(fraction), exponent)
+	if !ok {
+		return Decimal{}, fmt.Errorf("CryptomarketSDKError: scale of decimal %q out of range", value)
+	}
+	if scale < 0 {
+		coefficient.Mul(coefficient, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coefficient: coefficient, scale: scale}, nil
+}
+
+// scaleOf returns the scale of a decimal of the digits after the point and the exponent,
+// telling whether it fits in an int32
+func scaleOf(fractionDigits int, exponent int64) (int32, bool) {
+	scale := int64(fractionDigits) - exponent
+	if scale > math.MaxInt32 || scale < math.MinInt32+1 {
+		return 0, false
+	}
+	return int32(scale), true
+}
+
+// MustParse is Parse, panicking if the value is not a decimal. For constants
+func MustParse(value string) Decimal {
+	parsed, err := Parse(value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func isDigits(value string) bool {
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) coef() *big.Int {
+	if d.coefficient == nil {
+		return new(big.Int)
+	}
+	return d.coefficient
+}
+
+// Scale returns the number of digits after the point
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// rescale returns the coefficient of the decimal with a larger scale
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.coef()
+	}
+	return new(big.Int).Mul(d.coef(), pow10(scale-d.scale))
+}
+
+func maxScale(a, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+// String returns the decimal with all its digits after the point, as "0.010"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.coef()).String()
+	sign := ""
+	if d.coef().Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Float64 returns the closest float64 to the decimal. For display, as it may lose precision
+func (d Decimal) Float64() float64 {
+	value, _ := strconv.ParseFloat(d.String(), 64)
+	return value
+}
+
+// Sign returns -1, 0 or 1 for negative, zero and positive decimals
+func (d Decimal) Sign() int {
+	return d.coef().Sign()
+}
+
+// IsZero tells whether the decimal is 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coefficient: new(big.Int).Neg(d.coef()), scale: d.scale}
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{coefficient: new(big.Int).Abs(d.coef()), scale: d.scale}
+}
+
+// Add returns d + other, with the larger scale of both
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return Decimal{coefficient: new(big.Int).Add(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Sub returns d - other, with the larger scale of both
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return Decimal{coefficient: new(big.Int).Sub(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Mul returns d * other, with the sum of the scales of both.
+// Panics if the sum does not fit in an int32
+func (d Decimal) Mul(other Decimal) Decimal {
+	scale := int64(d.scale) + int64(other.scale)
+	if scale > math.MaxInt32 {
+		panic("decimal: scale of product out of range")
+	}
+	return Decimal{coefficient: new(big.Int).Mul(d.coef(), other.coef()), scale: int32(scale)}
+}
+
+// Div returns d / other with scale digits after the point, rounded with the mode.
+// A negative scale is 0. Panics if other is 0, as integer division does
+func (d Decimal) Div(other Decimal, scale int32, mode RoundingMode) Decimal {
+	if other.IsZero() {
+		panic("decimal: division by zero")
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	numerator, denominator := d.coef(), other.coef()
+	// d / other * 10^scale = d.coef * 10^(scale + other.scale - d.scale) / other.coef
+	if shift := scale + other.scale - d.scale; shift >= 0 {
+		numerator = new(big.Int).Mul(numerator, pow10(shift))
+	} else {
+		denominator = new(big.Int).Mul(denominator, pow10(-shift))
+	}
+	return Decimal{coefficient: roundQuotient(numerator, denominator, mode), scale: scale}
+}
+
+// Cmp returns -1, 0 or 1 if d is less, equal or greater than other
+func (d Decimal) Cmp(other Decimal) int {
+	scale := maxScale(d, other)
+	return d.rescale(scale).Cmp(other.rescale(scale))
+}
+
+// Equal tells whether both decimals are the same number, as 1.50 and 1.5
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// LessThan tells whether d < other
+func (d Decimal) LessThan(other Decimal) bool {
+	return d.Cmp(other) < 0
+}
+
+// GreaterThan tells whether d > other
+func (d Decimal) GreaterThan(other Decimal) bool {
+	return d.Cmp(other) > 0
+}
+
+// Round returns the decimal with at most scale digits after the point, rounded with the mode.
+// Decimals with fewer digits are returned unchanged
+func (d Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if d.scale <= scale {
+		return d
+	}
+	return Decimal{coefficient: roundQuotient(d.coef(), pow10(d.scale-scale), mode), scale: scale}
+}
+
+// RoundToMultiple returns the multiple of step closest to d in the direction of the mode,
+// with the scale of step. Used to fit prices to a tick size and quantities to an increment.
+// Panics if step is 0
+func (d Decimal) RoundToMultiple(step Decimal, mode RoundingMode) Decimal {
+	if step.IsZero() {
+		panic("decimal: zero step")
+	}
+	scale := maxScale(d, step)
+	multiples := roundQuotient(d.rescale(scale), step.rescale(scale), mode)
+	return Decimal{coefficient: multiples.Mul(multiples, step.coef()), scale: step.scale}
+}
+
+// MarshalJSON marshals the decimal as a json string, as the exchange does
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON unmarshals a json string or number. Empty strings and null are 0
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if strings.HasPrefix(value, `"`) {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	}
+	return d.UnmarshalText([]byte(value))
+}
+
+// MarshalText marshals the decimal as its string
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a decimal. Empty text is 0
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Decimal{}
+		return nil
+	}
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,146 @@
+package decimal
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseAndString(t *testing.T) {
+	cases := map[string]string{
+		"0":         "0",
+		"0.010000":  "0.010000",
+		"-12.5":     "-12.5",
+		"+3":        "3",
+		".5":        "0.5",
+		"7.":        "7",
+		"1.5e3":     "1500",
+		"25e-4":     "0.0025",
+		"-0.000001": "-0.000001",
+	}
+	for input, expected := range cases {
+		parsed, err := Parse(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.String() != expected {
+			t.Errorf("%s parsed as %s, expected %s", input, parsed, expected)
+		}
+	}
+	for _, input := range []string{"", "-", ".", "1.2.3", "abc", "1e", "0x10"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("expected an error parsing %q", input)
+		}
+	}
+}
+
+func TestParseBounds(t *testing.T) {
+	for _, input := range []string{"1e1000", "1e-1000"} {
+		if _, err := Parse(input); err != nil {
+			t.Errorf("unexpected error parsing %q: %v", input, err)
+		}
+	}
+	for _, input := range []string{"1e1001", "1e-1001", "1e2147483647", "1e-2147483648"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("expected an error parsing %q", input)
+		}
+	}
+	if _, ok := scaleOf(math.MaxInt32, -1); ok {
+		t.Error("expected a scale over int32 out of range")
+	}
+	if _, ok := scaleOf(0, math.MaxInt32+1); ok {
+		t.Error("expected a scale under int32 out of range")
+	}
+	if scale, ok := scaleOf(3, -2); !ok || scale != 5 {
+		t.Errorf("unexpected scale %d", scale)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := MustParse("0.1"), MustParse("0.20")
+	if sum := a.Add(b); sum.String() != "0.30" {
+		t.Errorf("unexpected sum %s", sum)
+	}
+	if difference := a.Sub(b); difference.String() != "-0.10" {
+		t.Errorf("unexpected difference %s", difference)
+	}
+	if product := a.Mul(b); product.String() != "0.020" {
+		t.Errorf("unexpected product %s", product)
+	}
+	if quotient := NewFromInt(2).Div(NewFromInt(3), 4, RoundHalfUp); quotient.String() != "0.6667" {
+		t.Errorf("unexpected quotient %s", quotient)
+	}
+	if !MustParse("1.50").Equal(MustParse("1.5")) || !a.LessThan(b) || a.Cmp(b) != -1 {
+		t.Error("unexpected comparison")
+	}
+	if !Zero.IsZero() || Zero.String() != "0" || Zero.Add(a).String() != "0.1" {
+		t.Error("unexpected zero value")
+	}
+}
+
+func TestArithmeticScaleBounds(t *testing.T) {
+	if quotient := NewFromInt(7).Div(NewFromInt(2), -3, RoundHalfUp); quotient.String() != "4" || quotient.Scale() != 0 {
+		t.Errorf("unexpected quotient %s with scale %d", quotient, quotient.Scale())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("product out of range should panic")
+		}
+	}()
+	huge := New(1, math.MaxInt32)
+	huge.Mul(huge)
+}
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		value    string
+		mode     RoundingMode
+		expected string
+	}{
+		{"1.25", RoundDown, "1.2"},
+		{"-1.25", RoundDown, "-1.2"},
+		{"1.21", RoundUp, "1.3"},
+		{"-1.21", RoundUp, "-1.3"},
+		{"-1.21", RoundFloor, "-1.3"},
+		{"-1.29", RoundCeiling, "-1.2"},
+		{"1.25", RoundHalfUp, "1.3"},
+		{"1.25", RoundHalfDown, "1.2"},
+		{"1.25", RoundHalfEven, "1.2"},
+		{"1.35", RoundHalfEven, "1.4"},
+		{"-0.05", RoundHalfUp, "-0.1"},
+		{"1.2", RoundUp, "1.2"},
+	}
+	for _, c := range cases {
+		if rounded := MustParse(c.value).Round(1, c.mode); rounded.String() != c.expected {
+			t.Errorf("%s rounded with %d as %s, expected %s", c.value, c.mode, rounded, c.expected)
+		}
+	}
+	step := MustParse("0.05")
+	if rounded := MustParse("1.234").RoundToMultiple(step, RoundDown); rounded.String() != "1.20" {
+		t.Errorf("unexpected multiple %s", rounded)
+	}
+	if rounded := MustParse("1.234").RoundToMultiple(step, RoundHalfUp); rounded.String() != "1.25" {
+		t.Errorf("unexpected multiple %s", rounded)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var balance struct {
+		Available Decimal `json:"available"`
+		Reserved  Decimal `json:"reserved"`
+		Fee       Decimal `json:"fee"`
+	}
+	if err := json.Unmarshal([]byte(`{"available":"0.00010000","reserved":1.5,"fee":""}`), &balance); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"available":"0.00010000","reserved":"1.5","fee":"0"}` {
+		t.Fatalf("unexpected json %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"available":"1,5"}`), &balance); err == nil {
+		t.Fatal("expected an error")
+	}
+}
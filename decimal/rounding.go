@@ -0,0 +1,58 @@
+package decimal
+
+import "math/big"
+
+// RoundingMode is how a decimal is rounded to fewer digits
+type RoundingMode int
+
+const (
+	// RoundDown rounds towards zero
+	RoundDown RoundingMode = iota
+	// RoundUp rounds away from zero
+	RoundUp
+	// RoundFloor rounds towards negative infinity
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity
+	RoundCeiling
+	// RoundHalfUp rounds to the nearest, and halves away from zero
+	RoundHalfUp
+	// RoundHalfDown rounds to the nearest, and halves towards zero
+	RoundHalfDown
+	// RoundHalfEven rounds to the nearest, and halves to the even neighbour
+	RoundHalfEven
+)
+
+// roundQuotient returns numerator / denominator rounded to an integer with the mode
+func roundQuotient(numerator, denominator *big.Int, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+	// the sign of the exact result, as the quotient may be 0
+	negative := (numerator.Sign() < 0) != (denominator.Sign() < 0)
+	awayFromZero := false
+	switch mode {
+	case RoundUp:
+		awayFromZero = true
+	case RoundFloor:
+		awayFromZero = negative
+	case RoundCeiling:
+		awayFromZero = !negative
+	case RoundHalfUp, RoundHalfDown, RoundHalfEven:
+		half := new(big.Int).Abs(remainder)
+		half.Lsh(half, 1)
+		switch half.Cmp(new(big.Int).Abs(denominator)) {
+		case 1:
+			awayFromZero = true
+		case 0:
+			awayFromZero = mode == RoundHalfUp || (mode == RoundHalfEven && quotient.Bit(0) == 1)
+		}
+	}
+	if !awayFromZero {
+		return quotient
+	}
+	if negative {
+		return quotient.Sub(quotient, big.NewInt(1))
+	}
+	return quotient.Add(quotient, big.NewInt(1))
+}
@@ -0,0 +1,253 @@
+// Command mockgen generates the mocks of the interfaces declared in a file, as a
+// struct per interface with a function field per method.
+//
+//	go run ../internal/mockgen interfaces.go mocks.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const modelsPath = "github.com/cryptomkt/cryptomkt-go/v3/models"
+
+type method struct {
+	name    string
+	params  []*ast.Field
+	results []*ast.Field
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatal("usage: mockgen source destination")
+	}
+	source, destination := os.Args[1], os.Args[2]
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, source, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	generated, err := generate(file, source)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(destination, generated, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(file *ast.File, source string) ([]byte, error) {
+	interfaces := make(map[string]*ast.InterfaceType)
+	var names []string
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+				interfaces[typeSpec.Name.Name] = iface
+				names = append(names, typeSpec.Name.Name)
+			}
+		}
+	}
+	used := map[string]bool{"models": true}
+	body := new(bytes.Buffer)
+	for _, name := range names {
+		methods, err := methodsOf(name, interfaces)
+		if err != nil {
+			return nil, err
+		}
+		writeMock(body, name, methods, used)
+	}
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "// Code generated by mockgen from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(out, "package %s\n\n", file.Name.Name)
+	out.WriteString("import (\n")
+	standard := true
+	for _, path := range importsOf(file, used) {
+		if standard && strings.Contains(strings.Split(path, "/")[0], ".") {
+			standard = false
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(out, "\t%q\n", path)
+	}
+	out.WriteString(")\n\n")
+	out.WriteString("func errNotMocked(method string) error {\n")
+	out.WriteString("\treturn &models.SDKError{Message: method + \" is not mocked\"}\n}\n")
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// methodsOf returns the methods of an interface, with the ones of the embedded interfaces of the file
+func methodsOf(name string, interfaces map[string]*ast.InterfaceType) ([]method, error) {
+	iface, ok := interfaces[name]
+	if !ok {
+		return nil, fmt.Errorf("interface %s is not declared in the source", name)
+	}
+	var methods []method
+	for _, field := range iface.Methods.List {
+		switch fieldType := field.Type.(type) {
+		case *ast.FuncType:
+			var results []*ast.Field
+			if fieldType.Results != nil {
+				results = fieldType.Results.List
+			}
+			for _, methodName := range field.Names {
+				methods = append(methods, method{name: methodName.Name, params: fieldType.Params.List, results: results})
+			}
+		case *ast.Ident:
+			embedded, err := methodsOf(fieldType.Name, interfaces)
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, embedded...)
+		default:
+			return nil, fmt.Errorf("unsupported embedded type in %s", name)
+		}
+	}
+	return methods, nil
+}
+
+func writeMock(out *bytes.Buffer, name string, methods []method, used map[string]bool) {
+	mock := "Mock" + name
+	fmt.Fprintf(out, "\n// %s is a mock of %s. Each method calls the field of its name with the Func suffix,\n", mock, name)
+	out.WriteString("// and fails as not mocked if the field is nil\n")
+	fmt.Fprintf(out, "type %s struct {\n", mock)
+	defer fmt.Fprintf(out, "\nvar _ %s = (*%s)(nil)\n", name, mock)
+	for _, method := range methods {
+		params, _, _ := paramsOf(method.params, used)
+		fmt.Fprintf(out, "\t%sFunc func(%s)%s\n", method.name, params, resultsOf(method.results, used))
+	}
+	out.WriteString("}\n")
+	for _, method := range methods {
+		params, args, variadic := paramsOf(method.params, used)
+		results := resultsOf(method.results, used)
+		fmt.Fprintf(out, "\n// %s calls %sFunc\n", method.name, method.name)
+		fmt.Fprintf(out, "func (mock *%s) %s(%s)%s {\n", mock, method.name, params, results)
+		fmt.Fprintf(out, "\tif mock.%sFunc == nil {\n", method.name)
+		out.WriteString(notMockedReturn(mock+"."+method.name, method.results, used))
+		out.WriteString("\t}\n")
+		call := fmt.Sprintf("mock.%sFunc(%s", method.name, strings.Join(args, ", "))
+		if variadic {
+			call += "..."
+		}
+		call += ")"
+		if len(method.results) == 0 {
+			fmt.Fprintf(out, "\t%s\n}\n", call)
+		} else {
+			fmt.Fprintf(out, "\treturn %s\n}\n", call)
+		}
+	}
+}
+
+// paramsOf returns the params of a method with a name each, the names and whether the last is variadic
+func paramsOf(fields []*ast.Field, used map[string]bool) (string, []string, bool) {
+	var params, names []string
+	variadic := false
+	for _, field := range fields {
+		typeString := typeOf(field.Type, used)
+		_, variadic = field.Type.(*ast.Ellipsis)
+		if len(field.Names) == 0 {
+			name := "p" + strconv.Itoa(len(names))
+			names = append(names, name)
+			params = append(params, name+" "+typeString)
+			continue
+		}
+		for _, ident := range field.Names {
+			names = append(names, ident.Name)
+			params = append(params, ident.Name+" "+typeString)
+		}
+	}
+	return strings.Join(params, ", "), names, variadic
+}
+
+func resultsOf(fields []*ast.Field, used map[string]bool) string {
+	var results []string
+	for _, field := range fields {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			results = append(results, typeOf(field.Type, used))
+		}
+	}
+	switch len(results) {
+	case 0:
+		return ""
+	case 1:
+		return " " + results[0]
+	}
+	return " (" + strings.Join(results, ", ") + ")"
+}
+
+// notMockedReturn returns zero values, and the not mocked error as the last error result
+func notMockedReturn(name string, fields []*ast.Field, used map[string]bool) string {
+	if len(fields) == 0 {
+		return "\t\treturn\n"
+	}
+	out := new(bytes.Buffer)
+	var values []string
+	index := 0
+	for _, field := range fields {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			typeString := typeOf(field.Type, used)
+			if typeString == "error" {
+				values = append(values, fmt.Sprintf("errNotMocked(%q)", name))
+				continue
+			}
+			value := "r" + strconv.Itoa(index)
+			index++
+			fmt.Fprintf(out, "\t\tvar %s %s\n", value, typeString)
+			values = append(values, value)
+		}
+	}
+	fmt.Fprintf(out, "\t\treturn %s\n", strings.Join(values, ", "))
+	return out.String()
+}
+
+// typeOf returns the source of a type, marking the packages it uses
+func typeOf(expr ast.Expr, used map[string]bool) string {
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return types.ExprString(expr)
+}
+
+// importsOf returns the imports of the file used by the mocks, and the models package
+func importsOf(file *ast.File, used map[string]bool) []string {
+	paths := []string{modelsPath}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if used[name] && path != modelsPath {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,179 @@
+// Package market keeps the metadata of the symbols of the exchange, to fit orders to its rules
+// before they are sent:
+//
+//	registry, err := market.NewRegistry(ctx, client)
+//	defer registry.Close()
+//	price, err := registry.RoundPrice("EOSETH", decimal.MustParse("0.0461234"), decimal.RoundDown)
+package market
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// Source is the source of the symbols, as a rest.Client
+type Source interface {
+	GetSymbols(ctx context.Context, arguments ...args.Argument) (map[string]models.Symbol, error)
+}
+
+// Registry is a cache of the symbols of the exchange, loaded once and refreshed in the background.
+// Safe for concurrent use
+type Registry struct {
+	source   Source
+	lock     *sync.RWMutex
+	symbols  map[string]models.Symbol
+	interval time.Duration
+	onError  func(error)
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// Option is an option of a registry
+type Option func(*Registry)
+
+// WithRefreshInterval sets the time between refreshes of the symbols. Default is an hour, 0 never refreshes them
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(registry *Registry) {
+		registry.interval = interval
+	}
+}
+
+// WithErrorHandler sets a function called with the errors of the background refreshes.
+// The symbols of the last successful load are kept on errors
+func WithErrorHandler(handler func(error)) Option {
+	return func(registry *Registry) {
+		registry.onError = handler
+	}
+}
+
+// NewRegistry loads the symbols of the source and refreshes them in the background until Close
+func NewRegistry(ctx context.Context, source Source, options ...Option) (*Registry, error) {
+	registry := &Registry{
+		source:   source,
+		lock:     new(sync.RWMutex),
+		interval: time.Hour,
+		onError:  func(error) {},
+		done:     make(chan struct{}),
+	}
+	for _, option := range options {
+		option(registry)
+	}
+	if err := registry.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	refreshCtx, cancel := context.WithCancel(context.Background())
+	registry.cancel = cancel
+	go registry.refreshEvery(refreshCtx)
+	return registry, nil
+}
+
+func (registry *Registry) refreshEvery(ctx context.Context) {
+	defer close(registry.done)
+	if registry.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(registry.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := registry.Refresh(ctx); err != nil && ctx.Err() == nil {
+				registry.onError(err)
+			}
+		}
+	}
+}
+
+// Refresh loads the symbols of the source now
+func (registry *Registry) Refresh(ctx context.Context) error {
+	symbols, err := registry.source.GetSymbols(ctx)
+	if err != nil {
+		return err
+	}
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	registry.symbols = symbols
+	return nil
+}
+
+// Close stops the background refreshes
+func (registry *Registry) Close() {
+	registry.cancel()
+	<-registry.done
+}
+
+// Symbol returns a symbol by its id. Unknown symbols are errors matching models.ErrInvalidSymbol
+func (registry *Registry) Symbol(id string) (models.Symbol, error) {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+	symbol, ok := registry.symbols[id]
+	if !ok {
+		return models.Symbol{}, &models.SDKError{Message: "unknown symbol " + id, Err: models.ErrInvalidSymbol}
+	}
+	return symbol, nil
+}
+
+// Symbols returns all the symbols by id
+func (registry *Registry) Symbols() map[string]models.Symbol {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+	symbols := make(map[string]models.Symbol, len(registry.symbols))
+	for id, symbol := range registry.symbols {
+		symbols[id] = symbol
+	}
+	return symbols
+}
+
+// RoundPrice rounds the price to the tick size of the symbol with the mode,
+// as decimal.RoundDown, decimal.RoundUp or decimal.RoundHalfUp
+func (registry *Registry) RoundPrice(id string, price decimal.Decimal, mode decimal.RoundingMode) (decimal.Decimal, error) {
+	symbol, err := registry.Symbol(id)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return symbol.RoundPrice(price, mode), nil
+}
+
+// RoundQuantity rounds the quantity to the quantity increment of the symbol with the mode,
+// as decimal.RoundDown, decimal.RoundUp or decimal.RoundHalfUp
+func (registry *Registry) RoundQuantity(id string, quantity decimal.Decimal, mode decimal.RoundingMode) (decimal.Decimal, error) {
+	symbol, err := registry.Symbol(id)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return symbol.RoundQuantity(quantity, mode), nil
+}
+
+// MinNotional returns the value in the quote currency of the smallest order of the symbol at the price
+func (registry *Registry) MinNotional(id string, price decimal.Decimal) (decimal.Decimal, error) {
+	symbol, err := registry.Symbol(id)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return symbol.MinNotional(price), nil
+}
+
+// Split returns the base and quote currencies of the symbol
+func (registry *Registry) Split(id string) (base, quote string, err error) {
+	symbol, err := registry.Symbol(id)
+	if err != nil {
+		return "", "", err
+	}
+	return symbol.BaseCurrency, symbol.QuoteCurrency, nil
+}
+
+// Suspended tells whether trading of the symbol is suspended
+func (registry *Registry) Suspended(id string) (bool, error) {
+	symbol, err := registry.Symbol(id)
+	if err != nil {
+		return false, err
+	}
+	return symbol.Suspended(), nil
+}
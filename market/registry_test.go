@@ -0,0 +1,88 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/cryptomkttest"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+func TestRounding(t *testing.T) {
+	server := cryptomkttest.NewServer()
+	defer server.Close()
+	registry, err := NewRegistry(context.Background(), server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer registry.Close()
+	price := decimal.MustParse("20000.125")
+	for mode, expected := range map[decimal.RoundingMode]string{
+		decimal.RoundDown:   "20000.12",
+		decimal.RoundUp:     "20000.13",
+		decimal.RoundHalfUp: "20000.13",
+	} {
+		rounded, err := registry.RoundPrice("BTCUSDT", price, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rounded.String() != expected {
+			t.Fatalf("expected %s, got %s", expected, rounded)
+		}
+	}
+	quantity, err := registry.RoundQuantity("BTCUSDT", decimal.MustParse("0.123456"), decimal.RoundDown)
+	if err != nil || quantity.String() != "0.12345" {
+		t.Fatalf("unexpected quantity %s, %v", quantity, err)
+	}
+	notional, err := registry.MinNotional("BTCUSDT", decimal.MustParse("20000"))
+	if err != nil || !notional.Equal(decimal.MustParse("0.2")) {
+		t.Fatalf("unexpected min notional %s, %v", notional, err)
+	}
+	base, quote, err := registry.Split("ETHBTC")
+	if err != nil || base != "ETH" || quote != "BTC" {
+		t.Fatalf("unexpected split %s %s, %v", base, quote, err)
+	}
+	if _, _, err := registry.Split("NOPE"); !errors.Is(err, models.ErrInvalidSymbol) {
+		t.Fatalf("expected an invalid symbol, got %v", err)
+	}
+}
+
+// countingSource counts the loads of its symbols
+type countingSource struct {
+	loads int32
+}
+
+func (source *countingSource) GetSymbols(ctx context.Context, arguments ...args.Argument) (map[string]models.Symbol, error) {
+	status := args.SymbolStatusWorking
+	if atomic.AddInt32(&source.loads, 1) > 1 {
+		status = args.SymbolStatusSuspended
+	}
+	return map[string]models.Symbol{"EOSETH": {Status: status}}, nil
+}
+
+func TestRefresh(t *testing.T) {
+	source := &countingSource{}
+	registry, err := NewRegistry(context.Background(), source, WithRefreshInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer registry.Close()
+	if suspended, _ := registry.Suspended("EOSETH"); suspended {
+		t.Fatal("should be working before a refresh")
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if suspended, _ := registry.Suspended("EOSETH"); suspended {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("should be suspended after a refresh")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
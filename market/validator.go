@@ -0,0 +1,111 @@
+package market
+
+import (
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// Validator checks new orders against the rules of the exchange before they are sent.
+// Give it to the clients with rest.WithOrderValidator or websocket.WithOrderValidator
+//
+//	validator := market.NewValidator(registry)
+//	client := rest.NewClientWithOptions(rest.WithCredentials(apiKey, apiSecret), rest.WithOrderValidator(validator))
+//	_, err := client.CreateSpotOrder(ctx, args.Symbol("EOSETH"), args.Side(args.SideBuy), args.Quantity("1"))
+//	var invalid *models.ValidationError
+//	if errors.As(err, &invalid) {
+//		fmt.Println(invalid.Violations) // price: required for limit orders
+//	}
+type Validator struct {
+	registry *Registry
+}
+
+// NewValidator creates a validator checking the symbols, prices and quantities against the symbols of
+// the registry. Without registry only the arguments required by the type of the order are checked
+func NewValidator(registry *Registry) *Validator {
+	return &Validator{registry: registry}
+}
+
+// ValidateOrder returns a *models.ValidationError with the rules broken by the order, or nil
+func (validator *Validator) ValidateOrder(order args.OrderRequest) error {
+	violations := validator.violationsOf(order)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &models.ValidationError{ClientOrderID: order.ClientOrderID, Violations: violations}
+}
+
+func (validator *Validator) violationsOf(order args.OrderRequest) []models.Violation {
+	var violations []models.Violation
+	violate := func(argument, message string) {
+		violations = append(violations, models.Violation{Argument: argument, Message: message})
+	}
+	var symbol *models.Symbol
+	if order.Symbol == "" {
+		violate("symbol", "required")
+	} else if validator.registry != nil {
+		if found, err := validator.registry.Symbol(order.Symbol); err != nil {
+			violate("symbol", "unknown symbol "+order.Symbol)
+		} else if found.Suspended() {
+			violate("symbol", "trading of "+order.Symbol+" is suspended")
+		} else {
+			symbol = &found
+		}
+	}
+	if order.Side != args.SideBuy && order.Side != args.SideSell {
+		violate("side", "must be buy or sell")
+	}
+	orderType := order.Type
+	if orderType == "" {
+		orderType = args.OrderLimit
+	}
+	var increment, tickSize decimal.Decimal
+	if symbol != nil {
+		increment, tickSize = symbol.QuantityIncrement, symbol.TickSize
+	}
+	checkAmount := func(argument, value string, required bool, step decimal.Decimal, stepName string) {
+		if value == "" {
+			if required {
+				violate(argument, "required for "+string(orderType)+" orders")
+			}
+			return
+		}
+		if message := amountViolation(value, step, stepName); message != "" {
+			violate(argument, message)
+		}
+	}
+	checkAmount("quantity", order.Quantity, true, increment, "quantity increment")
+	checkAmount("price", order.Price, hasPrice(orderType), tickSize, "tick size")
+	checkAmount("stop_price", order.StopPrice, hasStopPrice(orderType), tickSize, "tick size")
+	if order.TimeInForce == args.TimeInForceGTD && order.ExpireTime == "" {
+		violate("expire_time", "required for GTD orders")
+	}
+	if order.PostOnly && (order.TimeInForce == args.TimeInForceIOC || order.TimeInForce == args.TimeInForceFOK) {
+		violate("post_only", "not allowed with "+string(order.TimeInForce)+" orders")
+	}
+	return violations
+}
+
+// amountViolation returns why an amount is invalid, or "" if it is valid. Steps of 0 are not checked
+func amountViolation(value string, step decimal.Decimal, stepName string) string {
+	amount, err := decimal.Parse(value)
+	if err != nil {
+		return "not a decimal number"
+	}
+	if amount.Sign() <= 0 {
+		return "must be positive"
+	}
+	if step.Sign() > 0 && !amount.RoundToMultiple(step, decimal.RoundDown).Equal(amount) {
+		return "not a multiple of the " + stepName + " " + step.String()
+	}
+	return ""
+}
+
+func hasPrice(orderType args.OrderType) bool {
+	return orderType == args.OrderLimit || orderType == args.OrderStopLimit || orderType == args.OrderTakeProfitLimit
+}
+
+func hasStopPrice(orderType args.OrderType) bool {
+	return orderType == args.OrderStopLimit || orderType == args.OrderStopMarket ||
+		orderType == args.OrderTakeProfitLimit || orderType == args.OrderTakeProfitMarket
+}
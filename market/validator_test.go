@@ -0,0 +1,103 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/cryptomkttest"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/cryptomkt/cryptomkt-go/v3/rest"
+	"github.com/cryptomkt/cryptomkt-go/v3/websocket"
+)
+
+func TestValidateOrder(t *testing.T) {
+	validator := NewValidator(nil)
+	for _, test := range []struct {
+		order    args.OrderRequest
+		argument string
+	}{
+		{args.OrderRequest{Symbol: "EOSETH", Side: args.SideBuy, Quantity: "1"}, "price"},
+		{args.OrderRequest{Symbol: "EOSETH", Side: args.SideBuy, Quantity: "1", Type: args.OrderStopLimit, Price: "1"}, "stop_price"},
+		{args.OrderRequest{Symbol: "EOSETH", Side: args.SideBuy, Quantity: "1", Type: args.OrderMarket, TimeInForce: args.TimeInForceGTD}, "expire_time"},
+		{args.OrderRequest{Symbol: "EOSETH", Side: args.SideBuy, Quantity: "1", Price: "1", TimeInForce: args.TimeInForceIOC, PostOnly: true}, "post_only"},
+		{args.OrderRequest{Symbol: "EOSETH", Side: args.SideBuy, Quantity: "-1", Type: args.OrderMarket}, "quantity"},
+		{args.OrderRequest{Symbol: "EOSETH", Side: "hold", Quantity: "1", Type: args.OrderMarket}, "side"},
+	} {
+		var invalid *models.ValidationError
+		if err := validator.ValidateOrder(test.order); !errors.As(err, &invalid) {
+			t.Fatalf("expected a validation error of %+v, got %v", test.order, err)
+		}
+		if len(invalid.Violations) != 1 || invalid.Violations[0].Argument != test.argument {
+			t.Fatalf("expected a violation of %s, got %v", test.argument, invalid)
+		}
+	}
+	valid := args.OrderRequest{Symbol: "EOSETH", Side: args.SideSell, Quantity: "1", Type: args.OrderStopMarket, StopPrice: "0.5"}
+	if err := validator.ValidateOrder(valid); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateAgainstSymbols(t *testing.T) {
+	server := cryptomkttest.NewServer()
+	defer server.Close()
+	server.SetSymbol("EOSUSDT", models.Symbol{BaseCurrency: "EOS", QuoteCurrency: "USDT", Status: args.SymbolStatusSuspended})
+	registry, err := NewRegistry(context.Background(), server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer registry.Close()
+	validator := NewValidator(registry)
+	for _, order := range []args.OrderRequest{
+		{Symbol: "BTCUSDT", Side: args.SideBuy, Quantity: "0.000001", Price: "20000"},
+		{Symbol: "BTCUSDT", Side: args.SideBuy, Quantity: "0.01", Price: "20000.001"},
+		{Symbol: "EOSUSDT", Side: args.SideBuy, Quantity: "1", Price: "1"},
+		{Symbol: "NOPE", Side: args.SideBuy, Quantity: "1", Price: "1"},
+	} {
+		if err := validator.ValidateOrder(order); !errors.Is(err, models.ErrInvalidOrder) {
+			t.Fatalf("expected an invalid order of %+v, got %v", order, err)
+		}
+	}
+}
+
+func TestClientsValidateBeforeSending(t *testing.T) {
+	server := cryptomkttest.NewServer()
+	defer server.Close()
+	server.SetSpotBalance("USDT", "1000")
+	registry, err := NewRegistry(context.Background(), server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer registry.Close()
+	validator := NewValidator(registry)
+	wsClient, err := server.SpotTradingClient(websocket.WithOrderValidator(validator))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wsClient.Close()
+	restClient := server.Client(rest.WithOrderValidator(validator))
+	arguments := []args.Argument{args.Symbol("BTCUSDT"), args.Side(args.SideBuy), args.Quantity("0.01"), args.Price("19000.005")}
+	if _, err := restClient.CreateSpotOrder(context.Background(), arguments...); !errors.Is(err, models.ErrInvalidOrder) {
+		t.Fatalf("expected an invalid order over rest, got %v", err)
+	}
+	if _, err := wsClient.CreateSpotOrder(context.Background(), arguments...); !errors.Is(err, models.ErrInvalidOrder) {
+		t.Fatalf("expected an invalid order over websocket, got %v", err)
+	}
+	_, err = restClient.CreateSpotOrderList(context.Background(),
+		args.Contingency(args.ContingencyAllOrNone),
+		args.Orders([]args.OrderRequest{
+			{Symbol: "BTCUSDT", Side: args.SideBuy, Quantity: "0.01", Price: "19000"},
+			{Symbol: "BTCUSDT", Side: args.SideBuy, Quantity: "0.01"},
+		}),
+	)
+	if !errors.Is(err, models.ErrInvalidOrder) {
+		t.Fatalf("expected an invalid order list, got %v", err)
+	}
+	if orders := server.ActiveOrders(); len(orders) != 0 {
+		t.Fatalf("no order should reach the exchange, got %+v", orders)
+	}
+	if _, err := restClient.CreateSpotOrder(context.Background(), args.Symbol("BTCUSDT"), args.Side(args.SideBuy), args.Quantity("0.01"), args.Price("19000")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,5 +1,7 @@
 package models
 
+import "github.com/cryptomkt/cryptomkt-go/v3/args"
+
 func FromOrderbookJsonToOrderbook(obJson OrderBookJson) OrderBook {
 	asks := make([]BookLevel, 0, len(obJson.Ask))
 	for _, level := range obJson.Ask {
@@ -21,3 +23,38 @@ func FromOrderbookJsonToOrderbook(obJson OrderBookJson) OrderBook {
 		Bid:       bids,
 	}
 }
+
+// FromReportToOrder converts an execution report of the websocket api to an order of the rest api.
+// A trade report gives the trade as the only trade of the order
+func FromReportToOrder(report Report) Order {
+	order := Order{
+		ID:                    report.ID,
+		ClientOrderID:         report.ClientOrderID,
+		Symbol:                report.Symbol,
+		Side:                  string(report.Side),
+		Status:                report.Status,
+		Type:                  report.OrderType,
+		TimeInForce:           report.TimeInForce,
+		Quantity:              report.Quantity,
+		QuantityCumulative:    report.QuantityCumulative,
+		Price:                 report.Price,
+		StopPrice:             report.StopPrice,
+		ExpireTime:            report.ExpireTime,
+		PostOnly:              report.PostOnly,
+		OriginalClientOrderID: report.OriginalClientOrderID,
+		CreatedAt:             report.CreatedAt,
+		UpdatedAt:             report.UpdatedAt,
+		OrderListID:           report.OrderListID,
+	}
+	if report.ReportType == args.ReportTrade {
+		order.Trades = []TradeOfOrder{{
+			ID:        report.TradeID,
+			Price:     report.TradePrice,
+			Quantity:  report.TradeQuantity,
+			Fee:       report.TradeFee,
+			Taker:     report.TradeTaker,
+			Timestamp: report.UpdatedAt,
+		}}
+	}
+	return order
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for the common exchange errors. Use them with errors.Is
+//
+//	if errors.Is(err, models.ErrInsufficientFunds) {
+//		...
+//	}
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrInvalidSymbol     = errors.New("invalid symbol")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrAuthFailed        = errors.New("authentication failed")
+	ErrInvalidOrder      = errors.New("invalid order")
+	// ErrStaleConnection is a websocket connection without pongs or subscription data in time
+	ErrStaleConnection = errors.New("stale connection")
+)
+
+// exchange error codes
+//
+// https://api.exchange.cryptomkt.com/#error-codes
+const (
+	ErrorCodeTooManyRequests          = 429
+	ErrorCodeAuthorizationRequired    = 1001
+	ErrorCodeAuthorizationFailed      = 1002
+	ErrorCodeActionForbidden          = 1003
+	ErrorCodeUnsupportedAuthorization = 1004
+	ErrorCodeSymbolNotFound           = 2001
+	ErrorCodeCurrencyNotFound         = 2002
+	ErrorCodeInsufficientFunds        = 20001
+	ErrorCodeOrderNotFound            = 20002
+	ErrorCodeDuplicateClientOrderID   = 20008
+)
+
+// APIError is an error from the exchange.
+//
+// Status, RequestID, Path and Timestamp are taken from the error metadata of rest responses,
+// and are empty for websocket errors.
+type APIError struct {
+	Code        int    `json:"code"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
+	Status      int    `json:"-"`
+	RequestID   string `json:"-"`
+	Path        string `json:"-"`
+	Timestamp   string `json:"-"`
+}
+
+func (err APIError) String() string {
+	return fmt.Sprintf("CryptomarketAPIError: (code=%d) %s. %s", err.Code, err.Message, err.Description)
+}
+
+func (err *APIError) Error() string {
+	return err.String()
+}
+
+// Is matches the sentinel errors of the package by the error code
+func (err *APIError) Is(target error) bool {
+	switch target {
+	case ErrInsufficientFunds:
+		return err.Code == ErrorCodeInsufficientFunds
+	case ErrOrderNotFound:
+		return err.Code == ErrorCodeOrderNotFound
+	case ErrInvalidSymbol:
+		return err.Code == ErrorCodeSymbolNotFound
+	case ErrRateLimited:
+		return err.Code == ErrorCodeTooManyRequests || err.Status == http.StatusTooManyRequests
+	case ErrAuthFailed:
+		switch err.Code {
+		case ErrorCodeAuthorizationRequired,
+			ErrorCodeAuthorizationFailed,
+			ErrorCodeActionForbidden,
+			ErrorCodeUnsupportedAuthorization:
+			return true
+		}
+		return err.Status == http.StatusUnauthorized
+	}
+	return false
+}
+
+// ErrorMetadata is the data asociated with an error
+// from the exchange
+type ErrorMetadata struct {
+	Timestamp string    `json:"timestamp"`
+	Path      string    `json:"path"`
+	APIError  *APIError `json:"error"`
+	RequestID string    `json:"request_id"`
+	Status    int       `json:"status"`
+}
+
+// Err returns the api error with the metadata of the response, or nil if there is no api error
+func (metadata ErrorMetadata) Err() *APIError {
+	if metadata.APIError == nil {
+		return nil
+	}
+	apiError := *metadata.APIError
+	apiError.Status = metadata.Status
+	apiError.RequestID = metadata.RequestID
+	apiError.Path = metadata.Path
+	apiError.Timestamp = metadata.Timestamp
+	return &apiError
+}
+
+// SDKError is an error produced by the sdk, as a missing argument or a malformed response
+type SDKError struct {
+	Message string
+	Err     error
+}
+
+func (err *SDKError) Error() string {
+	if err.Err == nil {
+		return "CryptomarketSDKError: " + err.Message
+	}
+	return "CryptomarketSDKError: " + err.Message + ": " + err.Err.Error()
+}
+
+func (err *SDKError) Unwrap() error {
+	return err.Err
+}
+
+// Violation is a rule of the exchange broken by an argument of an order
+type Violation struct {
+	// Argument is the name of the param of the argument, as "price" or "stop_price"
+	Argument string
+	Message  string
+}
+
+// ValidationError is an order rejected by the client side validation, before any request to the exchange.
+// Matches ErrInvalidOrder with errors.Is
+type ValidationError struct {
+	ClientOrderID string
+	Violations    []Violation
+}
+
+func (err *ValidationError) Error() string {
+	messages := make([]string, 0, len(err.Violations))
+	for _, violation := range err.Violations {
+		messages = append(messages, violation.Argument+": "+violation.Message)
+	}
+	order := "invalid order"
+	if err.ClientOrderID != "" {
+		order += " " + err.ClientOrderID
+	}
+	return "CryptomarketSDKError: " + order + ": " + strings.Join(messages, "; ")
+}
+
+// Is matches ErrInvalidOrder
+func (err *ValidationError) Is(target error) bool {
+	return target == ErrInvalidOrder
+}
+
+// TransportError is an error in the communication with the exchange, as a failed connection or a timeout.
+// Wraps the error of the underlying transport
+type TransportError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (err *TransportError) Error() string {
+	return "CryptomarketSDKError: Can't make the request: " + err.Err.Error()
+}
+
+func (err *TransportError) Unwrap() error {
+	return err.Err
+}
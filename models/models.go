@@ -1,25 +1,24 @@
 package models
 
 import (
-	"fmt"
-
 	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
 )
 
 type Currency struct {
-	FullName            string    `json:"full_name"`
-	Crypto              bool      `json:"crypto"`
-	PayinEnabled        bool      `json:"payin_enabled"`
-	PayoutEnabled       bool      `json:"payout_enabled"`
-	TransferEnabled     bool      `json:"transfer_enabled"`
-	PrecisionTransfer   string    `json:"precision_transfer"`
-	Sign                string    `json:"sign"`
-	QRPrefix            string    `json:"qr_prefix"`
-	CryptoPaymentIDName string    `json:"crypto_payment_id_name"`
-	CryptoExplorer      string    `json:"crypto_explorer"`
-	AccountTopOrder     int64     `json:"account_top_order"`
-	Delisted            bool      `json:"delisted"`
-	Networks            []Network `json:"networks"`
+	FullName            string          `json:"full_name"`
+	Crypto              bool            `json:"crypto"`
+	PayinEnabled        bool            `json:"payin_enabled"`
+	PayoutEnabled       bool            `json:"payout_enabled"`
+	TransferEnabled     bool            `json:"transfer_enabled"`
+	PrecisionTransfer   decimal.Decimal `json:"precision_transfer"`
+	Sign                string          `json:"sign"`
+	QRPrefix            string          `json:"qr_prefix"`
+	CryptoPaymentIDName string          `json:"crypto_payment_id_name"`
+	CryptoExplorer      string          `json:"crypto_explorer"`
+	AccountTopOrder     int64           `json:"account_top_order"`
+	Delisted            bool            `json:"delisted"`
+	Networks            []Network       `json:"networks"`
 }
 
 type Network struct {
@@ -31,8 +30,8 @@ type Network struct {
 	Default             bool              `json:"default"`
 	PayinEnabled        bool              `json:"payin_enabled"`
 	PayoutEnabled       bool              `json:"payout_enabled"`
-	PrecisionPayout     string            `json:"presicion_payout"`
-	PayoutFee           string            `json:"payout_fee"`
+	PrecisionPayout     decimal.Decimal   `json:"presicion_payout"`
+	PayoutFee           decimal.Decimal   `json:"payout_fee"`
 	PayoutIsPaymentID   bool              `json:"payout_is_payment_id"`
 	PayinPaymentID      bool              `json:"payin_payment_id"`
 	PayinConfirmations  int               `json:"payin_confirmation"`
@@ -50,9 +49,9 @@ type Network struct {
 
 // Balance is the amount of currency a user have
 type Balance struct {
-	Currency  string `json:"currency"`
-	Available string `json:"available"`
-	Reserved  string `json:"reserved"`
+	Currency  string          `json:"currency"`
+	Available decimal.Decimal `json:"available"`
+	Reserved  decimal.Decimal `json:"reserved"`
 	// ReservedMargin string `json:"reserved_margin"`
 }
 
@@ -62,9 +61,9 @@ type SubAccountBalances struct {
 }
 
 type Price struct {
-	Currency  string `json:"currency"`
-	Price     string `json:"price"`
-	Timestamp string `json:"timestamp"`
+	Currency  string          `json:"currency"`
+	Price     decimal.Decimal `json:"price"`
+	Timestamp Time            `json:"timestamp"`
 }
 
 type PriceHistory struct {
@@ -73,47 +72,47 @@ type PriceHistory struct {
 }
 
 type HistoryPoint struct {
-	Timestamp string `json:"timestamp"`
-	Open      string `json:"open"`
-	Close     string `json:"close"`
-	Min       string `json:"min"`
-	Max       string `json:"max"`
+	Timestamp Time            `json:"timestamp"`
+	Open      decimal.Decimal `json:"open"`
+	Close     decimal.Decimal `json:"close"`
+	Min       decimal.Decimal `json:"min"`
+	Max       decimal.Decimal `json:"max"`
 }
 
 // PublicTrade is the available information from public trades
 type PublicTrade struct {
-	ID        int64  `json:"id"`
-	Price     string `json:"price"`
-	Quantity  string `json:"qty"`
-	Side      string `json:"side"`
-	Timestamp string `json:"timestamp"`
+	ID        int64           `json:"id"`
+	Price     decimal.Decimal `json:"price"`
+	Quantity  decimal.Decimal `json:"qty"`
+	Side      string          `json:"side"`
+	Timestamp Time            `json:"timestamp"`
 }
 
 // BookLevel agregates orders by price in a symbol
 type BookLevel struct {
-	Price  string `json:"price"`
-	Amount string `json:"amount"`
+	Price  decimal.Decimal `json:"price"`
+	Amount decimal.Decimal `json:"amount"`
 }
 
 // OrderBook is the current state of a symbol
 type OrderBookJson struct {
-	Ask       [][]string `json:"ask"`
-	Bid       [][]string `json:"bid"`
-	Timestamp string     `json:"timestamp"`
+	Ask       [][]decimal.Decimal `json:"ask"`
+	Bid       [][]decimal.Decimal `json:"bid"`
+	Timestamp Time                `json:"timestamp"`
 }
 
 // OrderBook is the current state of a symbol
 type OrderBook struct {
 	Ask       []BookLevel `json:"ask"`
 	Bid       []BookLevel `json:"bid"`
-	Timestamp string      `json:"timestamp"`
+	Timestamp Time        `json:"timestamp"`
 }
 
 // TradingCommission is the asociated cost to trade in the exchange
 type TradingCommission struct {
-	Symbol   string `json:"symbol"`
-	TakeRate string `json:"take_rate"`
-	MakeRate string `json:"make_rate"`
+	Symbol   string          `json:"symbol"`
+	TakeRate decimal.Decimal `json:"take_rate"`
+	MakeRate decimal.Decimal `json:"make_rate"`
 }
 
 // Symbol is a market made of two currencies being exchanged
@@ -122,13 +121,42 @@ type Symbol struct {
 	BaseCurrency       string                `json:"base_currency"`
 	QuoteCurrency      string                `json:"quote_currency"`
 	Status             args.SymbolStatusType `json:"status"`
-	QuantityIncrement  string                `json:"quantity_increment"`
-	TickSize           string                `json:"tick_size"`
-	TakeRate           string                `json:"take_rate"`
-	MakeRate           string                `json:"make_rate"`
+	QuantityIncrement  decimal.Decimal       `json:"quantity_increment"`
+	TickSize           decimal.Decimal       `json:"tick_size"`
+	TakeRate           decimal.Decimal       `json:"take_rate"`
+	MakeRate           decimal.Decimal       `json:"make_rate"`
 	FeeCurrency        string                `json:"fee_currency"`
 	MarginTrading      bool                  `json:"margin_trading"`
-	MaxInitialLeverage string                `json:"max_initial_leverage"`
+	MaxInitialLeverage decimal.Decimal       `json:"max_initial_leverage"`
+}
+
+// RoundPrice rounds the price to a multiple of the tick size with the mode, as decimal.RoundDown,
+// decimal.RoundUp or decimal.RoundHalfUp. Without tick size the price is unchanged
+func (symbol *Symbol) RoundPrice(price decimal.Decimal, mode decimal.RoundingMode) decimal.Decimal {
+	if symbol.TickSize.Sign() <= 0 {
+		return price
+	}
+	return price.RoundToMultiple(symbol.TickSize, mode)
+}
+
+// RoundQuantity rounds the quantity to a multiple of the quantity increment with the mode, as decimal.RoundDown,
+// decimal.RoundUp or decimal.RoundHalfUp. Without quantity increment the quantity is unchanged
+func (symbol *Symbol) RoundQuantity(quantity decimal.Decimal, mode decimal.RoundingMode) decimal.Decimal {
+	if symbol.QuantityIncrement.Sign() <= 0 {
+		return quantity
+	}
+	return quantity.RoundToMultiple(symbol.QuantityIncrement, mode)
+}
+
+// MinNotional returns the value in the quote currency of the smallest order at the price,
+// a quantity increment
+func (symbol *Symbol) MinNotional(price decimal.Decimal) decimal.Decimal {
+	return symbol.QuantityIncrement.Mul(price)
+}
+
+// Suspended tells whether trading of the symbol is suspended
+func (symbol *Symbol) Suspended() bool {
+	return symbol.Status == args.SymbolStatusSuspended
 }
 
 // Order is the abstraction of an order in a symbol in the exchange
@@ -140,43 +168,43 @@ type Order struct {
 	Status                args.OrderStatusType `json:"status"`
 	Type                  args.OrderType       `json:"type"`
 	TimeInForce           args.TimeInForceType `json:"time_in_force"`
-	Quantity              string               `json:"quantity"`
-	QuantityCumulative    string               `json:"quantity_cumulative"`
-	Price                 string               `json:"price"`
-	StopPrice             string               `json:"stop_price"`
-	ExpireTime            string               `json:"expire_time"`
+	Quantity              decimal.Decimal      `json:"quantity"`
+	QuantityCumulative    decimal.Decimal      `json:"quantity_cumulative"`
+	Price                 decimal.Decimal      `json:"price"`
+	StopPrice             decimal.Decimal      `json:"stop_price"`
+	ExpireTime            Time                 `json:"expire_time"`
 	PostOnly              bool                 `json:"post_only"`
 	OriginalClientOrderID string               `json:"original_client_order_id"`
-	CreatedAt             string               `json:"created_at"`
-	UpdatedAt             string               `json:"updated_at"`
+	CreatedAt             Time                 `json:"created_at"`
+	UpdatedAt             Time                 `json:"updated_at"`
 	Trades                []TradeOfOrder       `json:"trades"`
 	Contingency           args.ContingencyType `json:"contingency_type"`
 	OrderListID           string               `json:"order_list_id"`
-	AveragePrice          string               `json:"price_average"`
+	AveragePrice          decimal.Decimal      `json:"price_average"`
 }
 
 // TradeOfOrder is the trade information of trades of an order
 type TradeOfOrder struct {
-	ID        int64  `json:"id"`
-	Price     string `json:"price"`
-	Quantity  string `json:"quantity"`
-	Fee       string `json:"fee"`
-	Taker     bool   `json:"taker"`
-	Timestamp string `json:"timestamp"`
+	ID        int64           `json:"id"`
+	Price     decimal.Decimal `json:"price"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Fee       decimal.Decimal `json:"fee"`
+	Taker     bool            `json:"taker"`
+	Timestamp Time            `json:"timestamp"`
 }
 
 // Trade is a movement of currency where the user takes part
 type Trade struct {
-	ID            int64         `json:"id"`
-	OrderID       int64         `json:"order_id"`
-	ClientOrderID string        `json:"client_order_id"`
-	Symbol        string        `json:"symbol"`
-	Side          args.SideType `json:"side"`
-	Quantity      string        `json:"quantity"`
-	Price         string        `json:"price"`
-	Fee           string        `json:"fee"`
-	Timestamp     string        `json:"timestamp"`
-	Taker         bool          `json:"taker"`
+	ID            int64           `json:"id"`
+	OrderID       int64           `json:"order_id"`
+	ClientOrderID string          `json:"client_order_id"`
+	Symbol        string          `json:"symbol"`
+	Side          args.SideType   `json:"side"`
+	Quantity      decimal.Decimal `json:"quantity"`
+	Price         decimal.Decimal `json:"price"`
+	Fee           decimal.Decimal `json:"fee"`
+	Timestamp     Time            `json:"timestamp"`
+	Taker         bool            `json:"taker"`
 }
 
 // Transaction is a movement of currency,
@@ -186,28 +214,28 @@ type Transaction struct {
 	Status         args.TransactionStatusType  `json:"status"`
 	Type           args.TransactionTypeType    `json:"type"`
 	SubType        args.TransactionSubTypeType `json:"subtype"`
-	CreatedAt      string                      `json:"created_at"`
-	UpdatedAt      string                      `json:"updated_at"`
-	LastActivityAt string                      `json:"last_activity_at"`
+	CreatedAt      Time                        `json:"created_at"`
+	UpdatedAt      Time                        `json:"updated_at"`
+	LastActivityAt Time                        `json:"last_activity_at"`
 	Native         NativeTransaction           `json:"native"`
 	Meta           MetaTransaction             `json:"meta"`
 	CommitRisk     CommitRisk                  `json:"commit_risk"`
 }
 
 type NativeTransaction struct {
-	ID            string   `json:"tx_id"`
-	Index         int64    `json:"index"`
-	Currency      string   `json:"currency"`
-	Amount        string   `json:"amount"`
-	Fee           string   `json:"fee"`
-	Address       string   `json:"address"`
-	PaymentID     string   `json:"payment_id"`
-	Hash          string   `json:"hash"`
-	OffchainID    string   `json:"offchain_id"`
-	Confirmations int64    `json:"confirmations"`
-	PublicComment string   `json:"public_comment"`
-	ErrorCode     string   `json:"error_code"`
-	Senders       []string `json:"senders"`
+	ID            string          `json:"tx_id"`
+	Index         int64           `json:"index"`
+	Currency      string          `json:"currency"`
+	Amount        decimal.Decimal `json:"amount"`
+	Fee           decimal.Decimal `json:"fee"`
+	Address       string          `json:"address"`
+	PaymentID     string          `json:"payment_id"`
+	Hash          string          `json:"hash"`
+	OffchainID    string          `json:"offchain_id"`
+	Confirmations int64           `json:"confirmations"`
+	PublicComment string          `json:"public_comment"`
+	ErrorCode     string          `json:"error_code"`
+	Senders       []string        `json:"senders"`
 }
 
 type MetaTransaction struct {
@@ -220,12 +248,12 @@ type MetaTransaction struct {
 	TargetCurrency    string                         `json:"target_currency"`
 	WalletAddress     string                         `json:"wallet_address"`
 	TransactionHash   string                         `json:"tx_hash"`
-	TargetAmount      string                         `json:"target_amount"`
-	SourceAmount      string                         `json:"source_amount"`
+	TargetAmount      decimal.Decimal                `json:"target_amount"`
+	SourceAmount      decimal.Decimal                `json:"source_amount"`
 	Status            args.MetaTransactionStatusType `json:"status"`
-	CreatedAt         string                         `json:"created_at"`
-	UpdatedAt         string                         `json:"updated_at"`
-	DeletedAt         string                         `json:"deleted_at"`
+	CreatedAt         Time                           `json:"created_at"`
+	UpdatedAt         Time                           `json:"updated_at"`
+	DeletedAt         Time                           `json:"deleted_at"`
 	PaymentMethodType string                         `json:"payment_method_type"`
 }
 
@@ -261,13 +289,13 @@ type PayoutCryptoAddress struct {
 // Candle is an OHLC representation of the market
 // This version uses Max instead of High nad Min instead of Low
 type Candle struct {
-	Timestamp   string `json:"timestamp"`
-	Open        string `json:"open"`
-	Close       string `json:"close"`
-	High        string `json:"max"`
-	Low         string `json:"min"`
-	Volume      string `json:"volume"`
-	VolumeQuote string `json:"volume_quote"`
+	Timestamp   Time            `json:"timestamp"`
+	Open        decimal.Decimal `json:"open"`
+	Close       decimal.Decimal `json:"close"`
+	High        decimal.Decimal `json:"max"`
+	Low         decimal.Decimal `json:"min"`
+	Volume      decimal.Decimal `json:"volume"`
+	VolumeQuote decimal.Decimal `json:"volume_quote"`
 }
 
 type ConvertedCandles struct {
@@ -280,50 +308,29 @@ type ConvertedCandlesOfSymbol struct {
 }
 
 type Ticker struct {
-	Timestamp   string `json:"timestamp"`
-	Open        string `json:"open"`
-	Close       string `json:"close"`
-	Last        string `json:"last"`
-	High        string `json:"high"`
-	Low         string `json:"low"`
-	Volume      string `json:"volume"`
-	VolumeQuote string `json:"volume_quote"`
-	Ask         string `json:"ask"`
-	Bid         string `json:"bid"`
-}
-
-func (ticker *Ticker) GetLast() string {
-	if ticker.Last != "" {
+	Timestamp   Time            `json:"timestamp"`
+	Open        decimal.Decimal `json:"open"`
+	Close       decimal.Decimal `json:"close"`
+	Last        decimal.Decimal `json:"last"`
+	High        decimal.Decimal `json:"high"`
+	Low         decimal.Decimal `json:"low"`
+	Volume      decimal.Decimal `json:"volume"`
+	VolumeQuote decimal.Decimal `json:"volume_quote"`
+	Ask         decimal.Decimal `json:"ask"`
+	Bid         decimal.Decimal `json:"bid"`
+}
+
+func (ticker *Ticker) GetLast() decimal.Decimal {
+	if !ticker.Last.IsZero() {
 		return ticker.Last
 	}
 	return ticker.Close
 }
 
-func (ticker *Ticker) GetClose() string {
+func (ticker *Ticker) GetClose() decimal.Decimal {
 	return ticker.GetLast()
 }
 
-// Error is an error from the exchange
-type APIError struct {
-	Code        int    `json:"code"`
-	Message     string `json:"message"`
-	Description string `json:"description"`
-}
-
-func (err APIError) String() string {
-	return fmt.Sprintf("CryptomarketAPIError: (code=%d) %s. %s", err.Code, err.Message, err.Description)
-}
-
-// ErrorMetadata is the data asociated with an error
-// from the exchange
-type ErrorMetadata struct {
-	Timestamp string    `json:"timestamp"`
-	Path      string    `json:"path"`
-	APIError  *APIError `json:"error"`
-	RequestID string    `json:"request_id"`
-	Status    int       `json:"status"`
-}
-
 // Report is used for websocket trading reports.
 type Report struct {
 	ID                    int64                `json:"id"`
@@ -333,34 +340,34 @@ type Report struct {
 	Status                args.OrderStatusType `json:"status"`
 	OrderType             args.OrderType       `json:"type"`
 	TimeInForce           args.TimeInForceType `json:"time_in_force"`
-	Quantity              string               `json:"quantity"`
-	Price                 string               `json:"price"`
-	QuantityCumulative    string               `json:"quantity_cumulative"`
+	Quantity              decimal.Decimal      `json:"quantity"`
+	Price                 decimal.Decimal      `json:"price"`
+	QuantityCumulative    decimal.Decimal      `json:"quantity_cumulative"`
 	PostOnly              bool                 `json:"post_only"`
 	OrderListID           string               `json:"order_list_id"`
-	CreatedAt             string               `json:"created_at"`
-	UpdatedAt             string               `json:"updated_at"`
-	StopPrice             string               `json:"stopPrice"`
-	ExpireTime            string               `json:"expire_time"`
+	CreatedAt             Time                 `json:"created_at"`
+	UpdatedAt             Time                 `json:"updated_at"`
+	StopPrice             decimal.Decimal      `json:"stopPrice"`
+	ExpireTime            Time                 `json:"expire_time"`
 	OriginalClientOrderID string               `json:"original_client_order_id"`
 	TradeID               int64                `json:"trade_id"`
-	TradeQuantity         string               `json:"trade_quantity"`
-	TradePrice            string               `json:"trade_price"`
-	TradeFee              string               `json:"trade_fee"`
+	TradeQuantity         decimal.Decimal      `json:"trade_quantity"`
+	TradePrice            decimal.Decimal      `json:"trade_price"`
+	TradeFee              decimal.Decimal      `json:"trade_fee"`
 	TradeTaker            bool                 `json:"trade_taker"`
 	ReportType            args.ReportType      `json:"report_type"`
 }
 
 type AmountLock struct {
-	ID                int64  `json:"id"`
-	Currency          string `json:"currency"`
-	Amount            string `json:"amount"`
-	DateEnd           string `json:"date_end"`
-	Description       string `json:"description"`
-	Cancelled         bool   `json:"cancelled"`
-	CancelledAt       string `json:"cancelled_at"`
-	CancelDescription string `json:"cancel_description"`
-	CreatedAt         string `json:"created_at"`
+	ID                int64           `json:"id"`
+	Currency          string          `json:"currency"`
+	Amount            decimal.Decimal `json:"amount"`
+	DateEnd           Time            `json:"date_end"`
+	Description       string          `json:"description"`
+	Cancelled         bool            `json:"cancelled"`
+	CancelledAt       Time            `json:"cancelled_at"`
+	CancelDescription string          `json:"cancel_description"`
+	CreatedAt         Time            `json:"created_at"`
 }
 
 type IDResponse struct {
@@ -388,8 +395,8 @@ type BooleanResponse struct {
 }
 
 type FeeResponse struct {
-	Fee         string `json:"fee"`
-	NetworkCode string `json:"networkCode"`
+	Fee         decimal.Decimal `json:"fee"`
+	NetworkCode string          `json:"networkCode"`
 }
 
 type FeesHashResponse struct {
@@ -407,13 +414,13 @@ type ACLSettings struct {
 	DepositAddressGenerationEnabled bool   `json:"deposit_address_generation_enabled"`
 	WithdrawEnabled                 bool   `json:"withdraw_enabled"`
 	Description                     string `json:"description"`
-	CreatedAt                       string `json:"created_at"`
-	UpdatedAt                       string `json:"updated_at"`
+	CreatedAt                       Time   `json:"created_at"`
+	UpdatedAt                       Time   `json:"updated_at"`
 }
 
 type Fee struct {
-	Fee        string `json:"fee"`
-	NetworkFee string `json:"networkFee"`
-	Amount     string `json:"amount"`
-	Currency   string `json:"currency"`
+	Fee        decimal.Decimal `json:"fee"`
+	NetworkFee decimal.Decimal `json:"networkFee"`
+	Amount     decimal.Decimal `json:"amount"`
+	Currency   string          `json:"currency"`
 }
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time is a timestamp of the exchange. The rest api sends them as ISO 8601 strings and the websocket
+// feeds as milliseconds since the epoch, and Time unmarshals both and marshals back as it was received.
+// The zero Time is an empty string
+//
+//	if order.CreatedAt.Before(time.Now().Add(-time.Hour)) {
+//		...
+//	}
+type Time struct {
+	time.Time
+	// millis tells whether the time was received as milliseconds
+	millis bool
+	// digits is the number of digits of the fraction of second of an ISO 8601 string
+	digits int
+}
+
+// NewTime returns the time as the exchange sends ISO 8601 strings, in UTC with milliseconds
+func NewTime(t time.Time) Time {
+	return Time{Time: t.UTC().Truncate(time.Millisecond), digits: 3}
+}
+
+// NewTimeFromMillis returns the time of the milliseconds since the epoch, as the websocket feeds send them
+func NewTimeFromMillis(millis int64) Time {
+	return Time{Time: time.UnixMilli(millis).UTC(), millis: true}
+}
+
+// ParseTime parses an ISO 8601 string as "2021-06-02T17:52:35.135Z". An empty string is the zero Time
+func ParseTime(value string) (Time, error) {
+	if value == "" {
+		return Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return Time{}, &SDKError{Message: fmt.Sprintf("invalid time %q", value), Err: err}
+	}
+	return Time{Time: parsed, digits: fractionDigits(value)}, nil
+}
+
+func fractionDigits(value string) int {
+	point := strings.IndexByte(value, '.')
+	if point < 0 {
+		return 0
+	}
+	digits := 0
+	for _, char := range value[point+1:] {
+		if char < '0' || char > '9' {
+			break
+		}
+		digits++
+	}
+	return digits
+}
+
+// Millis returns the milliseconds since the epoch
+func (t Time) Millis() int64 {
+	return t.UnixMilli()
+}
+
+// String returns the time as the exchange sent it, an ISO 8601 string or the milliseconds
+func (t Time) String() string {
+	if t.millis {
+		return strconv.FormatInt(t.Millis(), 10)
+	}
+	if t.IsZero() {
+		return ""
+	}
+	layout := "2006-01-02T15:04:05"
+	if t.digits > 0 {
+		layout += "." + strings.Repeat("0", t.digits)
+	}
+	return t.Format(layout + "Z07:00")
+}
+
+// MarshalJSON marshals the time as the exchange sent it, a string or a number of milliseconds
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.millis {
+		return []byte(t.String()), nil
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON unmarshals an ISO 8601 string or a number of milliseconds. Empty strings and null are the zero Time
+func (t *Time) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		*t = Time{}
+		return nil
+	}
+	if !strings.HasPrefix(value, `"`) {
+		millis, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return &SDKError{Message: fmt.Sprintf("invalid time %s", value), Err: err}
+		}
+		*t = NewTimeFromMillis(millis)
+		return nil
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := ParseTime(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalText marshals the time as its string
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText parses an ISO 8601 string
+func (t *Time) UnmarshalText(text []byte) error {
+	parsed, err := ParseTime(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeRoundTrips(t *testing.T) {
+	for _, value := range []string{
+		`"2021-06-02T17:52:35.135Z"`,
+		`"2021-06-02T17:52:35.135000Z"`,
+		`"2021-06-02T17:52:35Z"`,
+		`"2021-06-02T14:52:35.1-03:00"`,
+		`1622656355135`,
+		`""`,
+	} {
+		var parsed Time
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			t.Fatal(err)
+		}
+		marshaled, err := json.Marshal(parsed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(marshaled) != value {
+			t.Fatalf("expected %s, got %s", value, marshaled)
+		}
+	}
+}
+
+func TestTimeOfBothRepresentations(t *testing.T) {
+	var trade struct {
+		ISO    Time `json:"iso"`
+		Millis Time `json:"millis"`
+	}
+	data := `{"iso":"2021-06-02T17:52:35.135Z","millis":1622656355135}`
+	if err := json.Unmarshal([]byte(data), &trade); err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(2021, 6, 2, 17, 52, 35, 135_000_000, time.UTC)
+	if !trade.ISO.Equal(expected) || !trade.Millis.Equal(expected) {
+		t.Fatalf("unexpected times %v and %v", trade.ISO, trade.Millis)
+	}
+	if err := json.Unmarshal([]byte(`{"iso":"yesterday"}`), &trade); err == nil {
+		t.Fatal("should fail to parse an invalid time")
+	}
+}
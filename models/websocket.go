@@ -1,76 +1,79 @@
 package models
 
-import "github.com/cryptomkt/cryptomkt-go/v3/args"
+import (
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+)
 
 type WSTradeFeed map[string][]WSTrade
 type WSTrade struct {
-	Timestamp int64  `json:"t"`
-	ID        int64  `json:"i"`
-	Price     string `json:"p"`
-	Quantity  string `json:"q"`
-	Side      string `json:"s"`
+	Timestamp Time            `json:"t"`
+	ID        int64           `json:"i"`
+	Price     decimal.Decimal `json:"p"`
+	Quantity  decimal.Decimal `json:"q"`
+	Side      string          `json:"s"`
 }
 
 type WSCandleFeed map[string][]WSCandle
 type WSCandle struct {
-	Timestamp   int64  `json:"t"`
-	Open        string `json:"o"`
-	Close       string `json:"c"`
-	High        string `json:"h"`
-	Low         string `json:"l"`
-	Volume      string `json:"v"`
-	VolumeQuote string `json:"q"`
+	Timestamp   Time            `json:"t"`
+	Open        decimal.Decimal `json:"o"`
+	Close       decimal.Decimal `json:"c"`
+	High        decimal.Decimal `json:"h"`
+	Low         decimal.Decimal `json:"l"`
+	Volume      decimal.Decimal `json:"v"`
+	VolumeQuote decimal.Decimal `json:"q"`
 }
 
 type MiniTickerFeed map[string]MiniTicker
 type MiniTicker struct {
-	Timestamp   int64  `json:"t"`
-	Open        string `json:"o"`
-	Last        string `json:"c"`
-	High        string `json:"h"`
-	Low         string `json:"l"`
-	VolumeBase  string `json:"v"`
-	VolumeQuote string `json:"q"`
+	Timestamp   Time            `json:"t"`
+	Open        decimal.Decimal `json:"o"`
+	Last        decimal.Decimal `json:"c"`
+	High        decimal.Decimal `json:"h"`
+	Low         decimal.Decimal `json:"l"`
+	VolumeBase  decimal.Decimal `json:"v"`
+	VolumeQuote decimal.Decimal `json:"q"`
 }
 
 type WSTickerFeed map[string]WSTicker
 type WSTicker struct {
-	Timestamp          int64  `json:"t"`
-	BestAsk            string `json:"a"`
-	BestAskQuantity    string `json:"A"`
-	BestBid            string `json:"b"`
-	BestBidQuantity    string `json:"B"`
-	Last               string `json:"c"`
-	Open               string `json:"o"`
-	High               string `json:"h"`
-	Low                string `json:"l"`
-	VolumeBase         string `json:"v"`
-	VolumeQuote        string `json:"q"`
-	PriceChange        string `json:"p"`
-	PriceChangePercent string `json:"P"`
-	LastTradeID        int64  `json:"L"`
+	Timestamp          Time            `json:"t"`
+	BestAsk            decimal.Decimal `json:"a"`
+	BestAskQuantity    decimal.Decimal `json:"A"`
+	BestBid            decimal.Decimal `json:"b"`
+	BestBidQuantity    decimal.Decimal `json:"B"`
+	Last               decimal.Decimal `json:"c"`
+	Open               decimal.Decimal `json:"o"`
+	High               decimal.Decimal `json:"h"`
+	Low                decimal.Decimal `json:"l"`
+	VolumeBase         decimal.Decimal `json:"v"`
+	VolumeQuote        decimal.Decimal `json:"q"`
+	PriceChange        decimal.Decimal `json:"p"`
+	PriceChangePercent decimal.Decimal `json:"P"`
+	LastTradeID        int64           `json:"L"`
 }
 
 type WSOrderbookFeed map[string]WSOrderbook
 type WSOrderbook struct {
-	Timestamp      int64      `json:"t"`
-	SequenceNumber int64      `json:"s"`
-	Ask            [][]string `json:"a"`
-	Bid            [][]string `json:"b"`
+	Timestamp      Time                `json:"t"`
+	SequenceNumber int64               `json:"s"`
+	Ask            [][]decimal.Decimal `json:"a"`
+	Bid            [][]decimal.Decimal `json:"b"`
 }
 
 type OrderbookTopFeed map[string]OrderbookTop
 type OrderbookTop struct {
-	Timestamp       int64  `json:"t"`
-	BestAsk         string `json:"a"`
-	BestAskQuantity string `json:"A"`
-	BestBid         string `json:"b"`
-	BestBidQuantity string `json:"B"`
+	Timestamp       Time            `json:"t"`
+	BestAsk         decimal.Decimal `json:"a"`
+	BestAskQuantity decimal.Decimal `json:"A"`
+	BestBid         decimal.Decimal `json:"b"`
+	BestBidQuantity decimal.Decimal `json:"B"`
 }
 
 type WSPrice struct {
-	Timestamp int64  `json:"t"`
-	Rate      string `json:"r"`
+	Timestamp Time            `json:"t"`
+	Rate      decimal.Decimal `json:"r"`
 }
 type PriceFeed map[string]WSPrice
 
@@ -0,0 +1,174 @@
+// Package ratelimit implements a client side token bucket limiter
+// keyed by the endpoint groups of the exchange rate limits.
+//
+// One Limiter can be shared between a rest.Client and a websocket
+// SpotTradingClient trading on the same account, so both count
+// against the same budget.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// Group is a set of endpoints sharing a rate limit on the exchange
+type Group string
+
+const (
+	// GroupMarketData are the public market data endpoints
+	GroupMarketData Group = "market_data"
+	// GroupTrading are the order placement, replacement and cancellation endpoints
+	GroupTrading Group = "trading"
+	// GroupOther are the rest of the private endpoints: balances, history, wallet and sub-accounts
+	GroupOther Group = "other"
+)
+
+// Rate is the allowed requests per second of a group, and the
+// number of requests that can be made in a burst
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// DefaultRates returns the rate limits documented by the exchange
+//
+// https://api.exchange.cryptomkt.com/#rate-limiting
+func DefaultRates() map[Group]Rate {
+	return map[Group]Rate{
+		GroupMarketData: {PerSecond: 30, Burst: 50},
+		GroupTrading:    {PerSecond: 300, Burst: 450},
+		GroupOther:      {PerSecond: 20, Burst: 30},
+	}
+}
+
+// Limiter is a set of token buckets, one per group. Safe for concurrent use.
+// Groups without a rate are not limited.
+type Limiter struct {
+	lock    *sync.Mutex
+	buckets map[Group]*bucket
+	now     func() time.Time
+}
+
+// NewLimiter creates a limiter with the given rates per group
+func NewLimiter(rates map[Group]Rate) *Limiter {
+	limiter := &Limiter{
+		lock:    new(sync.Mutex),
+		buckets: make(map[Group]*bucket),
+		now:     time.Now,
+	}
+	for group, rate := range rates {
+		if rate.PerSecond <= 0 {
+			continue
+		}
+		burst := rate.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		limiter.buckets[group] = &bucket{
+			rate:   rate.PerSecond,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   limiter.now(),
+		}
+	}
+	return limiter
+}
+
+// NewDefaultLimiter creates a limiter with the default rates of the exchange
+func NewDefaultLimiter() *Limiter {
+	return NewLimiter(DefaultRates())
+}
+
+// Wait blocks until a request of the group is allowed, or until the context is done.
+// Fails fast with a *models.SDKError matching models.ErrRateLimited if the context deadline
+// comes before the request would be allowed.
+func (limiter *Limiter) Wait(ctx context.Context, group Group) error {
+	limiter.lock.Lock()
+	bucket, ok := limiter.buckets[group]
+	if !ok {
+		limiter.lock.Unlock()
+		return nil
+	}
+	now := limiter.now()
+	delay := bucket.reserve(now)
+	if deadline, ok := ctx.Deadline(); ok && delay > 0 && deadline.Before(now.Add(delay)) {
+		bucket.cancel()
+		limiter.lock.Unlock()
+		return &models.SDKError{
+			Message: fmt.Sprintf("rate limit of %s would exceed the context deadline", group),
+			Err:     models.ErrRateLimited,
+		}
+	}
+	limiter.lock.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		limiter.lock.Lock()
+		bucket.cancel()
+		limiter.lock.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Allow reports whether a request of the group can be made now, consuming a token if so
+func (limiter *Limiter) Allow(group Group) bool {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	bucket, ok := limiter.buckets[group]
+	if !ok {
+		return true
+	}
+	bucket.refill(limiter.now())
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (bucket *bucket) refill(now time.Time) {
+	elapsed := now.Sub(bucket.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	bucket.last = now
+	bucket.tokens += elapsed * bucket.rate
+	if bucket.tokens > bucket.burst {
+		bucket.tokens = bucket.burst
+	}
+}
+
+// reserve takes a token, possibly going into debt, and returns how long
+// to wait for the token to be available
+func (bucket *bucket) reserve(now time.Time) time.Duration {
+	bucket.refill(now)
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+}
+
+func (bucket *bucket) cancel() {
+	bucket.tokens++
+	if bucket.tokens > bucket.burst {
+		bucket.tokens = bucket.burst
+	}
+}
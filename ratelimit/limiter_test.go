@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+func TestAllowConsumesBurst(t *testing.T) {
+	limiter := NewLimiter(map[Group]Rate{GroupTrading: {PerSecond: 1, Burst: 2}})
+	if !limiter.Allow(GroupTrading) || !limiter.Allow(GroupTrading) {
+		t.Fatal("burst should be allowed")
+	}
+	if limiter.Allow(GroupTrading) {
+		t.Fatal("should be limited after the burst")
+	}
+	if !limiter.Allow(GroupOther) {
+		t.Fatal("groups without rate should not be limited")
+	}
+}
+
+func TestWaitBlocksUntilAllowed(t *testing.T) {
+	limiter := NewLimiter(map[Group]Rate{GroupMarketData: {PerSecond: 20, Burst: 1}})
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, GroupMarketData); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("should have waited for tokens, waited %v", elapsed)
+	}
+}
+
+func TestWaitRespectsContext(t *testing.T) {
+	limiter := NewLimiter(map[Group]Rate{GroupOther: {PerSecond: 1, Burst: 1}})
+	limiter.Allow(GroupOther)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := limiter.Wait(ctx, GroupOther)
+	var sdkErr *models.SDKError
+	if !errors.As(err, &sdkErr) || !errors.Is(err, models.ErrRateLimited) {
+		t.Fatalf("should fail as rate limited by the context deadline, got %v", err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	limiter = NewLimiter(map[Group]Rate{GroupOther: {PerSecond: 1, Burst: 1}})
+	limiter.Allow(GroupOther)
+	if err := limiter.Wait(ctx, GroupOther); err != context.Canceled {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+}
@@ -1,52 +1,35 @@
 package rest
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/base64"
-	"encoding/hex"
 	"net/http"
 	"strconv"
-	"time"
-)
-
-func (client *httpclient) getCredentialForRequest(request *http.Request, body string) string {
-	timestamp := now()
-	message := getMessage(request, body, timestamp, client.window)
-	signature := getMessageSignature(client, message)
-	credential := client.apiKey + ":" + signature + ":" + timestamp
-	if client.window != 0 {
-		credential += (":" + strconv.FormatInt(int64(client.window), 10))
-	}
-	return "HS256 " + base64.StdEncoding.EncodeToString([]byte(credential))
-}
-
-func now() string {
-	return strconv.FormatInt(time.Now().UnixMilli(), 10)
-}
 
-func getMessageSignature(client *httpclient, msg string) string {
-	hash := hmac.New(sha256.New, []byte(client.apiSecret))
-	hash.Write([]byte(msg))
-	return hex.EncodeToString(hash.Sum(nil))
-}
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
 
-func getMessage(request *http.Request, body, timestamp string, window int) string {
-	message := request.Method + request.URL.Path
-	message = addBodyToMessageIfPresent(message, request.Method, body)
-	message += timestamp
-	if window != 0 {
-		message += strconv.FormatInt(int64(window), 10)
+func (client *httpclient) getCredentialForRequest(request *http.Request, body string) (string, error) {
+	ctx := request.Context()
+	credentials, err := client.authenticator.credentials(ctx)
+	if err != nil {
+		return "", err
 	}
-	return message
-}
-
-func addBodyToMessageIfPresent(msg, method, body string) string {
-	if len(body) == 0 {
-		return msg
+	window := client.authenticator.windowFor(ctx)
+	timestamp := client.clock.UnixMilli()
+	signature, err := credentials.signer().Sign(ctx, auth.Payload{
+		Method:    request.Method,
+		Path:      request.URL.Path,
+		Body:      body,
+		Timestamp: timestamp,
+		Window:    window,
+	})
+	if err != nil {
+		return "", &models.SDKError{Message: "unable to sign the request", Err: err}
 	}
-	if method == methodGet {
-		return msg + "?" + body
+	credential := credentials.APIKey + ":" + signature + ":" + strconv.FormatInt(timestamp, 10)
+	if window != 0 {
+		credential += (":" + strconv.FormatInt(int64(window), 10))
 	}
-	return msg + body
+	return "HS256 " + base64.StdEncoding.EncodeToString([]byte(credential)), nil
 }
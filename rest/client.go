@@ -3,11 +3,14 @@ package rest
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
 	"github.com/cryptomkt/cryptomkt-go/v3/internal"
+	"github.com/cryptomkt/cryptomkt-go/v3/ratelimit"
 
 	"github.com/cryptomkt/cryptomkt-go/v3/models"
 )
@@ -29,7 +32,11 @@ const (
 
 // Client handles all the comunication with the rest API
 type Client struct {
-	hclient httpclient
+	hclient      httpclient
+	retryPolicy  RetryPolicy
+	rateLimiter  *ratelimit.Limiter
+	interceptors []Interceptor
+	validator    OrderValidator
 }
 
 // NewClient creates a new rest client to communicate with the exchange.
@@ -41,9 +48,7 @@ type Client struct {
 //	apiSecret // the secret key of the user
 //	window // the window of execution for requests to the server in milliseconds. Max is 60_000 (miliseconds). Use 0 for default window (10 seconds)
 func NewClient(apiKey, apiSecret string, window int) *Client {
-	return &Client{
-		hclient: newHTTPClient(apiKey, apiSecret, window),
-	}
+	return NewClientWithOptions(WithCredentials(apiKey, apiSecret), WithWindow(window))
 }
 
 // NewPublicClient creates a new rest client with no credentials to communicate with the exchange.
@@ -51,21 +56,50 @@ func NewClient(apiKey, apiSecret string, window int) *Client {
 // Requests to the exchange via this client use the args package for arguments.
 // All requests accept contexts for cancellation
 func NewPublicClient() *Client {
+	return NewClientWithOptions()
+}
+
+// NewClientWithOptions creates a new rest client configured by the given options.
+// With no options it behaves as a public client targeting the production exchange.
+//
+//	client := rest.NewClientWithOptions(
+//		rest.WithCredentials(apiKey, apiSecret),
+//		rest.WithBaseURL("http://localhost:8080"),
+//		rest.WithTimeout(10*time.Second),
+//	)
+func NewClientWithOptions(options ...ClientOption) *Client {
+	config := newClientConfig(options)
 	return &Client{
-		hclient: newHTTPClient("", "", 0),
+		hclient:      newHTTPClient(config),
+		retryPolicy:  config.retryPolicy,
+		rateLimiter:  config.rateLimiter,
+		interceptors: config.interceptors,
+		validator:    config.orderValidator,
 	}
 }
 
 // ChangeCredentials changes the api and secret key used by the client for authentication. effectively permitting the use of the same http connection for the comunication to the api as another user.
+// Safe for concurrent use, but affects all the following requests of the client. To use other credentials in a single request see ContextWithCredentials
 func (client *Client) ChangeCredentials(apiKey, apiSecret string) {
-	client.hclient.apiKey = apiKey
-	client.hclient.apiSecret = apiSecret
+	client.hclient.authenticator.setProvider(NewStaticCredentials(apiKey, apiSecret))
+}
+
+// ChangeCredentialsProvider changes the provider of the credentials used by the client for authentication.
+func (client *Client) ChangeCredentialsProvider(provider CredentialsProvider) {
+	client.hclient.authenticator.setProvider(provider)
 }
 
 // ChangeWindow changes the window used by the client for authentication. effectively permitting the use a diferent window per request.
 // The window is the timeout in millis on the server to respond a request. Default is 10_000. Max is 60_000.
+// Safe for concurrent use, but affects all the following requests of the client. To use other window in a single request see ContextWithWindow
 func (client *Client) ChangeWindow(window int) {
-	client.hclient.window = window
+	client.hclient.authenticator.setWindow(window)
+}
+
+// ClockSkew returns the measured offset of the exchange clock from the local clock.
+// Always 0 unless the client was created with the WithClock option
+func (client *Client) ClockSkew() time.Duration {
+	return client.hclient.clock.Skew()
 }
 
 func (client *Client) publicGet(
@@ -156,7 +190,7 @@ func makeRequestData(
 	urlEncodedParams := args.BuildQuery(params)
 	jsonEncodedParams, err := json.Marshal(params)
 	if err != nil {
-		return nil, fmt.Errorf("CryptomarketSDKError: unable to encode params: %v", err)
+		return nil, &models.SDKError{Message: "unable to encode params", Err: err}
 	}
 	return &RequestData{
 		cxt:                ctx,
@@ -165,43 +199,112 @@ func makeRequestData(
 		urlEncodedPayload:  urlEncodedParams,
 		jsonEncodedPayload: string(jsonEncodedParams),
 		public:             public,
+		idempotent:         isIdempotent(method, endpoint, params),
+		params:             params,
 	}, nil
 }
 func (client *Client) doRequest(
 	requestData *RequestData,
 	model interface{},
 ) error {
-	data, err := client.hclient.makeRequest(requestData)
+	start := time.Now()
+	response, attempts, err := client.makeRequestWithRetries(requestData)
+	recordMeta(requestData.cxt, response, attempts, time.Since(start))
 	if err != nil {
 		return err
 	}
-	return client.handleResponseData(data, model)
+	if response == nil {
+		return &models.SDKError{Message: "empty response"}
+	}
+	return client.handleResponseData(response, model)
+}
+
+// makeRequestWithRetries returns the response of the last attempt and the number of attempts made
+func (client *Client) makeRequestWithRetries(requestData *RequestData) (response *Response, attempt int, err error) {
+	attempts := client.retryPolicy.attemptsFor(requestData)
+	for attempt = 1; ; attempt++ {
+		if err = client.waitRateLimit(requestData); err != nil {
+			return nil, attempt - 1, err
+		}
+		response, err = client.roundTrip(requestData)
+		if attempt >= attempts || !shouldRetry(response, err) || requestData.cxt.Err() != nil {
+			return response, attempt, err
+		}
+		if sleepErr := sleepContext(requestData.cxt, client.retryPolicy.delay(attempt, response)); sleepErr != nil {
+			return response, attempt, err
+		}
+	}
+}
+
+func (client *Client) roundTrip(requestData *RequestData) (*Response, error) {
+	if len(client.interceptors) == 0 {
+		return client.hclient.makeRequest(requestData)
+	}
+	request := &Request{
+		Method:   requestData.method,
+		Endpoint: requestData.endpoint,
+		Params:   requestData.params,
+		Body:     requestData.payload(),
+		Header:   make(http.Header),
+		Public:   requestData.public,
+	}
+	return client.interceptorChain(0, requestData)(requestData.cxt, request)
+}
+
+func (client *Client) interceptorChain(index int, requestData *RequestData) Next {
+	if index == len(client.interceptors) {
+		return func(ctx context.Context, request *Request) (*Response, error) {
+			return client.hclient.makeRequest(requestData.withRequest(ctx, request))
+		}
+	}
+	return func(ctx context.Context, request *Request) (*Response, error) {
+		return client.interceptors[index](ctx, request, client.interceptorChain(index+1, requestData))
+	}
 }
 
 func (client *Client) handleResponseData(
-	data []byte,
+	response *Response,
 	model interface{},
 ) error {
 	errorResponse := models.ErrorMetadata{}
-	json.Unmarshal(data, &errorResponse)
-	apiError := errorResponse.APIError
-	if apiError != nil { // is a real error
-		return fmt.Errorf(
-			"CryptomarketAPIError: (code=%v) %v. %v",
-			apiError.Code,
-			apiError.Message,
-			apiError.Description,
-		)
-	}
-	err := json.Unmarshal(data, model)
-	if err != nil {
-		return errors.New(
-			"CryptomarketSDKError: Failed to parse response data: " + err.Error(),
-		)
+	json.Unmarshal(response.Body, &errorResponse)
+	if apiError := errorResponse.Err(); apiError != nil { // is a real error
+		if apiError.Status == 0 {
+			apiError.Status = response.Status
+		}
+		return apiError
+	}
+	if response.Status >= http.StatusBadRequest {
+		return &models.APIError{
+			Code:    response.Status,
+			Message: http.StatusText(response.Status),
+			Status:  response.Status,
+		}
+	}
+	err := json.Unmarshal(response.Body, model)
+	if err != nil {
+		return &models.SDKError{Message: "Failed to parse response data", Err: err}
 	}
 	return nil
 }
 
+func (client *Client) waitRateLimit(requestData *RequestData) error {
+	if client.rateLimiter == nil {
+		return nil
+	}
+	return client.rateLimiter.Wait(requestData.cxt, rateLimitGroup(requestData.endpoint))
+}
+
+func rateLimitGroup(endpoint string) ratelimit.Group {
+	if strings.HasPrefix(endpoint, "public/") {
+		return ratelimit.GroupMarketData
+	}
+	if strings.HasPrefix(endpoint, endpointOrder) {
+		return ratelimit.GroupTrading
+	}
+	return ratelimit.GroupOther
+}
+
 // GetCurrencies gets a map of all currencies or specified currencies. indexed by id
 //
 // # Requires no API key Access Rights
@@ -542,9 +645,8 @@ func (client *Client) GetOrderBookOfSymbol(
 		return
 	}
 	response := models.OrderBookJson{
-		Ask:       make([][]string, 0),
-		Bid:       make([][]string, 0),
-		Timestamp: "",
+		Ask: make([][]decimal.Decimal, 0),
+		Bid: make([][]decimal.Decimal, 0),
 	}
 	err = client.publicGet(
 		ctx,
@@ -582,9 +684,8 @@ func (client *Client) GetOrderBookVolumeOfSymbol(
 		return
 	}
 	response := models.OrderBookJson{
-		Ask:       make([][]string, 0),
-		Bid:       make([][]string, 0),
-		Timestamp: "",
+		Ask: make([][]decimal.Decimal, 0),
+		Bid: make([][]decimal.Decimal, 0),
 	}
 	err = client.publicGet(
 		ctx,
@@ -654,7 +755,7 @@ func (client *Client) GetCandlesOfSymbol(
 	err = client.publicGet(
 		ctx,
 		endpointCandles+"/"+params[internal.ArgNameSymbol].(string),
-		nil,
+		params,
 		&result,
 	)
 	return
@@ -850,6 +951,9 @@ func (client *Client) CreateSpotOrder(
 	if err != nil {
 		return
 	}
+	if err = validateOrders(client.validator, params); err != nil {
+		return
+	}
 	err = client.post(ctx, endpointOrder, params, &result)
 	return
 }
@@ -941,6 +1045,9 @@ func (client *Client) CreateSpotOrderList(
 	if err != nil {
 		return
 	}
+	if err = validateOrders(client.validator, params); err != nil {
+		return
+	}
 	err = client.post(ctx, endpointOrderList, params, &result)
 	return
 }
@@ -1191,8 +1298,11 @@ func (client *Client) GetDepositCryptoAddressOfCurrency(
 	}
 	resposne := make([]models.CryptoAddress, 0)
 	err = client.privateGet(ctx, endpointCryptoAdress, params, &resposne)
+	if err != nil {
+		return
+	}
 	if len(resposne) < 1 {
-		return result, errors.New("CryptomarketSDKError: no such address")
+		return result, &models.SDKError{Message: "no such address"}
 	}
 	result = &resposne[0]
 	return
@@ -1414,7 +1524,7 @@ func (client *Client) GetEstimateWithdrawalFees(
 	}
 	jsonData, err := json.Marshal(params[internal.SDKArgNameFeeRequest])
 	if err != nil {
-		return nil, fmt.Errorf("CryptomarketSDKError: %v", err)
+		return nil, &models.SDKError{Message: "unable to encode fee requests", Err: err}
 	}
 	requestData := &RequestData{
 		cxt:                ctx,
@@ -1512,7 +1622,7 @@ func (client *Client) GetEstimateWithdrawalFees(
 func (client *Client) GetEstimateWithdrawFee(
 	ctx context.Context,
 	arguments ...args.Argument,
-) (result string, err error) {
+) (result decimal.Decimal, err error) {
 	params, err := args.BuildParams(
 		arguments,
 		internal.ArgNameCurrency,
@@ -1548,7 +1658,7 @@ func (client *Client) GetBulkEstimateWithdrawalFees(
 	}
 	jsonData, err := json.Marshal(params[internal.SDKArgNameFeeRequest])
 	if err != nil {
-		return nil, fmt.Errorf("CryptomarketSDKError: %v", err)
+		return nil, &models.SDKError{Message: "unable to encode fee requests", Err: err}
 	}
 	requestData := &RequestData{
 		cxt:                ctx,
@@ -1681,7 +1791,7 @@ func (client *Client) TransferBetweenWalletAndExchange(
 		return
 	}
 	if len(response) < 1 {
-		return transactionID, errors.New("CryptomarketSDKError: Bad response format")
+		return transactionID, &models.SDKError{Message: "Bad response format"}
 	}
 	transactionID = response[0]
 	return
@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+)
+
+func TestClientWithBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/3/public/currency/EOS" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if !strings.HasSuffix(r.Header.Get(headerUserAgent), " my-bot/1.0") {
+			t.Errorf("missing user agent suffix: %s", r.Header.Get(headerUserAgent))
+		}
+		w.Write([]byte(`{"full_name":"EOS","crypto":true}`))
+	}))
+	defer server.Close()
+	client := NewClientWithOptions(
+		WithBaseURL(server.URL+"/"),
+		WithUserAgentSuffix("my-bot/1.0"),
+	)
+	result, err := client.GetCurrency(context.Background(), args.Currency("EOS"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.FullName != "EOS" {
+		t.Fatalf("unexpected currency: %v", result)
+	}
+}
+
+func TestClientWithCredentialsSignsPrivateCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "HS256 ") {
+			t.Errorf("missing authorization header")
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	client := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithCredentials("key", "secret"),
+	)
+	if _, err := client.GetSpotTradingBalances(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithTimeout(10*time.Millisecond),
+	)
+	if _, err := client.GetCurrencies(context.Background()); err == nil {
+		t.Fatal("should fail by timeout")
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return fn(request)
+}
+
+func TestClientWithTransport(t *testing.T) {
+	called := false
+	transport := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		called = true
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       http.NoBody,
+			Request:    request,
+		}, nil
+	})
+	client := NewClientWithOptions(WithTransport(transport))
+	client.GetCurrencies(context.Background())
+	if !called {
+		t.Fatal("custom transport not used")
+	}
+}
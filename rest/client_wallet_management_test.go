@@ -175,7 +175,7 @@ func TestGetEstimateWithdrawFee(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if result == "" {
+	if result.IsZero() {
 		t.Fatal("should have a result")
 	}
 }
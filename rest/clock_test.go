@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
+)
+
+func TestClockCalibratesSignatures(t *testing.T) {
+	skew := time.Hour
+	var timestamps []int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(r.Header.Get("Authorization"), "HS256 "))
+		timestamp, _ := strconv.ParseInt(strings.Split(string(decoded), ":")[2], 10, 64)
+		timestamps = append(timestamps, timestamp)
+		serverTime := time.Now().Add(skew)
+		w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"timestamp":"` + serverTime.UTC().Format(time.RFC3339Nano) + `","error":{"code":1002,"message":"Authorization failed"}}`))
+	}))
+	defer server.Close()
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithCredentials("key", "secret"), WithClock(auth.NewClock()))
+	for i := 0; i < 2; i++ {
+		client.GetSpotTradingBalances(context.Background())
+	}
+	if measured := client.ClockSkew(); measured < skew-time.Second || measured > skew+time.Second {
+		t.Fatalf("expected a skew of about %v, got %v", skew, measured)
+	}
+	if offset := time.Duration(timestamps[1]-timestamps[0]) * time.Millisecond; offset < skew-time.Second {
+		t.Fatalf("second request should be signed with the server time, signed %v later", offset)
+	}
+}
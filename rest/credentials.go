@@ -0,0 +1,203 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// environment variables read by NewEnvCredentials
+const (
+	EnvAPIKey    = "CRYPTOMARKET_API_KEY"
+	EnvAPISecret = "CRYPTOMARKET_API_SECRET"
+)
+
+// Credentials are the api key and api secret of an account.
+//
+// If a Signer is given, it signs the requests instead of the api secret,
+// so the secret does not need to be in the process memory.
+type Credentials struct {
+	APIKey    string      `json:"apiKey"`
+	APISecret string      `json:"apiSecret"`
+	Signer    auth.Signer `json:"-"`
+}
+
+func (credentials Credentials) signer() auth.Signer {
+	if credentials.Signer != nil {
+		return credentials.Signer
+	}
+	return auth.NewHMACSigner(credentials.APISecret)
+}
+
+// CredentialsProvider supplies the credentials used to authenticate private calls.
+// Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// StaticCredentials is a provider of fixed credentials
+type StaticCredentials Credentials
+
+// NewStaticCredentials creates a provider of fixed credentials
+func NewStaticCredentials(apiKey, apiSecret string) StaticCredentials {
+	return StaticCredentials{APIKey: apiKey, APISecret: apiSecret}
+}
+
+// Credentials returns the fixed credentials
+func (credentials StaticCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials(credentials), nil
+}
+
+// EnvCredentials is a provider of credentials read from environment variables on every call
+type EnvCredentials struct {
+	APIKeyVar    string
+	APISecretVar string
+}
+
+// NewEnvCredentials creates a provider reading the CRYPTOMARKET_API_KEY and CRYPTOMARKET_API_SECRET environment variables
+func NewEnvCredentials() EnvCredentials {
+	return EnvCredentials{APIKeyVar: EnvAPIKey, APISecretVar: EnvAPISecret}
+}
+
+// Credentials reads the credentials from the environment. Fails if any variable is not set
+func (credentials EnvCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	apiKey, ok := os.LookupEnv(credentials.APIKeyVar)
+	if !ok {
+		return Credentials{}, &models.SDKError{Message: "missing environment variable " + credentials.APIKeyVar}
+	}
+	apiSecret, ok := os.LookupEnv(credentials.APISecretVar)
+	if !ok {
+		return Credentials{}, &models.SDKError{Message: "missing environment variable " + credentials.APISecretVar}
+	}
+	return Credentials{APIKey: apiKey, APISecret: apiSecret}, nil
+}
+
+// FileCredentials is a provider of credentials read from a json file, as
+//
+//	{"apiKey": "...", "apiSecret": "..."}
+//
+// The file is read again when its modification time changes, so keys can be rotated without restarting.
+type FileCredentials struct {
+	path        string
+	lock        *sync.Mutex
+	modTime     time.Time
+	credentials Credentials
+}
+
+// NewFileCredentials creates a provider reading the credentials from the json file at path
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{
+		path: path,
+		lock: new(sync.Mutex),
+	}
+}
+
+// Credentials returns the credentials of the file, reading it if it changed since the last call
+func (provider *FileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	info, err := os.Stat(provider.path)
+	if err != nil {
+		return Credentials{}, &models.SDKError{Message: "unable to read credentials file", Err: err}
+	}
+	if info.ModTime().Equal(provider.modTime) {
+		return provider.credentials, nil
+	}
+	data, err := os.ReadFile(provider.path)
+	if err != nil {
+		return Credentials{}, &models.SDKError{Message: "unable to read credentials file", Err: err}
+	}
+	var credentials Credentials
+	if err = json.Unmarshal(data, &credentials); err != nil {
+		return Credentials{}, &models.SDKError{Message: "unable to parse credentials file", Err: err}
+	}
+	provider.credentials = credentials
+	provider.modTime = info.ModTime()
+	return credentials, nil
+}
+
+// WithSigner sets the api key and the signer used to authenticate private calls, instead of an api secret
+func WithSigner(apiKey string, signer auth.Signer) ClientOption {
+	return func(config *clientConfig) {
+		config.credentialsProvider = StaticCredentials{APIKey: apiKey, Signer: signer}
+	}
+}
+
+// WithCredentialsProvider sets the provider of the credentials used to authenticate private calls
+func WithCredentialsProvider(provider CredentialsProvider) ClientOption {
+	return func(config *clientConfig) {
+		config.credentialsProvider = provider
+	}
+}
+
+type credentialsKey struct{}
+
+type windowKey struct{}
+
+// ContextWithCredentials returns a copy of the context that makes the requests done with it
+// use the given credentials instead of the ones of the client.
+// Lets one client serve many accounts concurrently.
+func ContextWithCredentials(ctx context.Context, credentials Credentials) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, credentials)
+}
+
+// ContextWithWindow returns a copy of the context that makes the requests done with it
+// use the given window instead of the one of the client.
+func ContextWithWindow(ctx context.Context, window int) context.Context {
+	return context.WithValue(ctx, windowKey{}, window)
+}
+
+// authenticator resolves the credentials and window of each request.
+// Shared by copies of the httpclient
+type authenticator struct {
+	lock     *sync.RWMutex
+	provider CredentialsProvider
+	window   int
+}
+
+func newAuthenticator(provider CredentialsProvider, window int) *authenticator {
+	if provider == nil {
+		provider = StaticCredentials{}
+	}
+	return &authenticator{
+		lock:     new(sync.RWMutex),
+		provider: provider,
+		window:   window,
+	}
+}
+
+func (authenticator *authenticator) setProvider(provider CredentialsProvider) {
+	authenticator.lock.Lock()
+	defer authenticator.lock.Unlock()
+	authenticator.provider = provider
+}
+
+func (authenticator *authenticator) setWindow(window int) {
+	authenticator.lock.Lock()
+	defer authenticator.lock.Unlock()
+	authenticator.window = window
+}
+
+func (authenticator *authenticator) credentials(ctx context.Context) (Credentials, error) {
+	if credentials, ok := ctx.Value(credentialsKey{}).(Credentials); ok {
+		return credentials, nil
+	}
+	authenticator.lock.RLock()
+	provider := authenticator.provider
+	authenticator.lock.RUnlock()
+	return provider.Credentials(ctx)
+}
+
+func (authenticator *authenticator) windowFor(ctx context.Context) int {
+	if window, ok := ctx.Value(windowKey{}).(int); ok {
+		return window
+	}
+	authenticator.lock.RLock()
+	defer authenticator.lock.RUnlock()
+	return authenticator.window
+}
@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
+)
+
+// newKeyEchoServer responds with the api key and window used to authenticate the request,
+// as the currency and available of a balance. No window is an available of 0
+func newKeyEchoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoded := strings.TrimPrefix(r.Header.Get("Authorization"), "HS256 ")
+		decoded, _ := base64.StdEncoding.DecodeString(encoded)
+		parts := strings.Split(string(decoded), ":")
+		window := ""
+		if len(parts) > 3 {
+			window = parts[3]
+		}
+		w.Write([]byte(`[{"currency":"` + parts[0] + `","available":"` + window + `"}]`))
+	}))
+}
+
+func authenticatedAs(t *testing.T, client *Client, ctx context.Context) (apiKey, window string) {
+	result, err := client.GetSpotTradingBalances(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result[0].Currency, result[0].Available.String()
+}
+
+func TestContextCredentialsOverride(t *testing.T) {
+	server := newKeyEchoServer()
+	defer server.Close()
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithCredentials("default", "secret"))
+	bg := context.Background()
+	var wg sync.WaitGroup
+	for _, key := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			ctx := ContextWithCredentials(bg, Credentials{APIKey: key, APISecret: "secret"})
+			if apiKey, _ := authenticatedAs(t, client, ctx); apiKey != key {
+				t.Errorf("expected key %s, got %s", key, apiKey)
+			}
+		}(key)
+	}
+	wg.Wait()
+	if apiKey, _ := authenticatedAs(t, client, bg); apiKey != "default" {
+		t.Fatalf("expected default key, got %s", apiKey)
+	}
+}
+
+func TestContextWindowOverride(t *testing.T) {
+	server := newKeyEchoServer()
+	defer server.Close()
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithCredentials("key", "secret"), WithWindow(10000))
+	if _, window := authenticatedAs(t, client, ContextWithWindow(context.Background(), 25000)); window != "25000" {
+		t.Fatalf("expected window 25000, got %s", window)
+	}
+	client.ChangeWindow(0)
+	if _, window := authenticatedAs(t, client, context.Background()); window != "0" {
+		t.Fatalf("expected no window, got %s", window)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	server := newKeyEchoServer()
+	defer server.Close()
+	os.Setenv(EnvAPIKey, "from-env")
+	os.Setenv(EnvAPISecret, "secret")
+	defer os.Unsetenv(EnvAPIKey)
+	defer os.Unsetenv(EnvAPISecret)
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithCredentialsProvider(NewEnvCredentials()))
+	if apiKey, _ := authenticatedAs(t, client, context.Background()); apiKey != "from-env" {
+		t.Fatalf("expected key from env, got %s", apiKey)
+	}
+	os.Unsetenv(EnvAPISecret)
+	if _, err := client.GetSpotTradingBalances(context.Background()); err == nil {
+		t.Fatal("should fail without secret")
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	server := newKeyEchoServer()
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(path, []byte(`{"apiKey":"from-file","apiSecret":"secret"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithCredentialsProvider(NewFileCredentials(path)))
+	if apiKey, _ := authenticatedAs(t, client, context.Background()); apiKey != "from-file" {
+		t.Fatalf("expected key from file, got %s", apiKey)
+	}
+}
+
+func TestWithSigner(t *testing.T) {
+	server := newKeyEchoServer()
+	defer server.Close()
+	var signed auth.Payload
+	signer := auth.SignerFunc(func(ctx context.Context, payload auth.Payload) (string, error) {
+		signed = payload
+		return "signature", nil
+	})
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithSigner("remote", signer))
+	if apiKey, _ := authenticatedAs(t, client, context.Background()); apiKey != "remote" {
+		t.Fatalf("expected remote key, got %s", apiKey)
+	}
+	if signed.Method != http.MethodGet || signed.Path != "/api/3/spot/balance" {
+		t.Fatalf("unexpected signed payload %+v", signed)
+	}
+}
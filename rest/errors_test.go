@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+func newErrorServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestAPIErrorIsTyped(t *testing.T) {
+	server := newErrorServer(
+		http.StatusBadRequest,
+		`{"timestamp":"2021-01-01T00:00:00.000Z","path":"/api/3/spot/order","request_id":"abc123","status":400,"error":{"code":20001,"message":"Insufficient funds","description":"Check that the funds are sufficient"}}`,
+	)
+	defer server.Close()
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithCredentials("key", "secret"))
+	_, err := client.CreateSpotOrder(
+		context.Background(),
+		args.Symbol("EOSETH"),
+		args.Side(args.SideBuy),
+		args.Quantity("1"),
+	)
+	if !errors.Is(err, models.ErrInsufficientFunds) {
+		t.Fatalf("expected insufficient funds, got %v", err)
+	}
+	if errors.Is(err, models.ErrOrderNotFound) {
+		t.Fatal("should not match other sentinels")
+	}
+	var apiError *models.APIError
+	if !errors.As(err, &apiError) {
+		t.Fatal("should be an api error")
+	}
+	if apiError.RequestID != "abc123" || apiError.Status != 400 || apiError.Path != "/api/3/spot/order" {
+		t.Fatalf("missing metadata: %+v", apiError)
+	}
+}
+
+func TestAPIErrorFromStatus(t *testing.T) {
+	server := newErrorServer(http.StatusTooManyRequests, `too many requests`)
+	defer server.Close()
+	client := NewClientWithOptions(WithBaseURL(server.URL))
+	_, err := client.GetCurrencies(context.Background())
+	if !errors.Is(err, models.ErrRateLimited) {
+		t.Fatalf("expected rate limited, got %v", err)
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	server := newErrorServer(http.StatusOK, `{}`)
+	server.Close()
+	client := NewClientWithOptions(WithBaseURL(server.URL))
+	_, err := client.GetCurrencies(context.Background())
+	var transportError *models.TransportError
+	if !errors.As(err, &transportError) {
+		t.Fatalf("expected a transport error, got %v", err)
+	}
+}
+
+func TestSDKError(t *testing.T) {
+	server := newErrorServer(http.StatusOK, `not json`)
+	defer server.Close()
+	client := NewClientWithOptions(WithBaseURL(server.URL))
+	_, err := client.GetCurrencies(context.Background())
+	var sdkError *models.SDKError
+	if !errors.As(err, &sdkError) {
+		t.Fatalf("expected an sdk error, got %v", err)
+	}
+}
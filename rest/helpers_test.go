@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
 	"github.com/cryptomkt/cryptomkt-go/v3/models"
 )
 
@@ -36,6 +37,15 @@ func checkNoNil(field interface{}, name string) (err error) {
 		if v == "" {
 			err = errMsg
 		}
+	case decimal.Decimal:
+		// a missing decimal is zero
+		if v.IsZero() {
+			err = errMsg
+		}
+	case models.Time:
+		if v.IsZero() {
+			err = errMsg
+		}
 	default:
 		if field == nil {
 			err = errMsg
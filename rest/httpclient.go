@@ -2,14 +2,18 @@ package rest
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"io"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
 )
 
 const (
-	apiURL                  = "https://api.exchange.cryptomkt.com"
+	defaultBaseURL          = "https://api.exchange.cryptomkt.com"
 	apiVersion              = "/api/3/"
 	headerContentType       = "Content-type"
 	headerUserAgent         = "User-Agent"
@@ -22,19 +26,21 @@ const (
 // accepts Get, Post, Put and Delete functions, all with parameters and return
 // the response bytes
 type httpclient struct {
-	client    *http.Client
-	apiKey    string
-	apiSecret string
-	window    int
+	client        *http.Client
+	baseURL       string
+	userAgent     string
+	authenticator *authenticator
+	clock         *auth.Clock
 }
 
 // New creates a new httpclient
-func newHTTPClient(apiKey, apiSecret string, window int) httpclient {
+func newHTTPClient(config *clientConfig) httpclient {
 	return httpclient{
-		client:    &http.Client{},
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
-		window:    window,
+		client:        config.buildHTTPClient(),
+		baseURL:       config.baseURL,
+		userAgent:     config.buildUserAgent(),
+		authenticator: newAuthenticator(config.credentialsProvider, config.window),
+		clock:         config.clock,
 	}
 }
 
@@ -45,19 +51,61 @@ type RequestData struct {
 	urlEncodedPayload  string
 	jsonEncodedPayload string
 	public             bool
+	idempotent         bool
+	params             map[string]interface{}
+	header             http.Header
+}
+
+// payload returns the encoded params as sent to the server, a json body for
+// post requests and an url encoded query or body for the rest
+func (requestData *RequestData) payload() string {
+	if requestData.method == methodPost {
+		return requestData.jsonEncodedPayload
+	}
+	return requestData.urlEncodedPayload
 }
 
-func (hclient httpclient) makeRequest(requestData *RequestData) (result []byte, err error) {
+// withRequest returns a copy of the request data updated with the request of an interceptor chain
+func (requestData *RequestData) withRequest(ctx context.Context, request *Request) *RequestData {
+	updated := *requestData
+	updated.cxt = ctx
+	updated.method = request.Method
+	updated.endpoint = request.Endpoint
+	updated.header = request.Header
+	if updated.method == methodPost {
+		updated.jsonEncodedPayload = request.Body
+	} else {
+		updated.urlEncodedPayload = request.Body
+	}
+	return &updated
+}
+
+func (hclient httpclient) makeRequest(requestData *RequestData) (*Response, error) {
 	request, err := hclient.buildRequest(requestData)
 	if err != nil {
 		return nil, err
 	}
+	start := time.Now()
 	response, err := hclient.client.Do(request)
 	if err != nil {
-		return nil, errors.New("CryptomarketSDKError: Can't make the request: " + err.Error())
+		return nil, &models.TransportError{
+			Method: requestData.method,
+			Path:   apiVersion + requestData.endpoint,
+			Err:    err,
+		}
 	}
 	defer response.Body.Close()
-	return readResponse(response)
+	body, err := readResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	hclient.observeServerTime(response, body, start, time.Now())
+	return &Response{
+		Status:  response.StatusCode,
+		Header:  response.Header,
+		Body:    body,
+		Latency: time.Since(start),
+	}, nil
 }
 
 func (hclient httpclient) buildRequest(requestData *RequestData) (*http.Request, error) {
@@ -98,21 +146,46 @@ func (hclient httpclient) buildOtherRequest(requestData *RequestData, urlEncoded
 }
 
 func (hclient httpclient) buildRequestHelper(requestData *RequestData, body io.Reader, query string) (*http.Request, error) {
-	request, err := http.NewRequestWithContext(requestData.cxt, requestData.method, apiURL+apiVersion+requestData.endpoint, body)
+	request, err := http.NewRequestWithContext(requestData.cxt, requestData.method, hclient.baseURL+apiVersion+requestData.endpoint, body)
 	if err != nil {
-		return nil, errors.New("CryptomarketSDKError: Can't build the request: " + err.Error())
+		return nil, &models.SDKError{Message: "Can't build the request", Err: err}
 	}
-	request.Header.Add(headerUserAgent, userAgentCryptomarketGo)
+	for key, values := range requestData.header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+	request.Header.Add(headerUserAgent, hclient.userAgent)
 	if !requestData.public {
-		request.Header.Add("Authorization", hclient.getCredentialForRequest(request, query))
+		credential, err := hclient.getCredentialForRequest(request, query)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Add("Authorization", credential)
 	}
 	return request, nil
 }
 
+// observeServerTime calibrates the clock with the Date header, and with the
+// millisecond timestamp of error responses
+func (hclient httpclient) observeServerTime(response *http.Response, body []byte, sent, received time.Time) {
+	if hclient.clock == nil {
+		return
+	}
+	hclient.clock.ObserveHeader(response.Header, sent, received)
+	if response.StatusCode < http.StatusBadRequest {
+		return
+	}
+	errorResponse := models.ErrorMetadata{}
+	if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Timestamp != "" {
+		hclient.clock.ObserveTimestamp(errorResponse.Timestamp, sent, received)
+	}
+}
+
 func readResponse(resp *http.Response) ([]byte, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.New("CryptomarketSDKError: Can't read the response body: " + err.Error())
+		return nil, &models.SDKError{Message: "Can't read the response body", Err: err}
 	}
 	return body, nil
 }
@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Request is a request to the exchange as seen by the interceptors.
+// Interceptors may modify it before calling the next step of the chain.
+type Request struct {
+	Method string
+	// Endpoint is the path of the request relative to the api version, as "spot/order"
+	Endpoint string
+	// Params are the arguments of the request. Changing them has no effect, change the Body instead
+	Params map[string]interface{}
+	// Body is the encoded params, a json body for post requests and an url encoded query or body for the rest.
+	// Is signed after the interceptor chain, so it is safe to change it
+	Body string
+	// Header are extra headers added to the request
+	Header http.Header
+	// Public is true for calls that need no authentication
+	Public bool
+}
+
+// Response is the raw response of the exchange
+type Response struct {
+	Status  int
+	Header  http.Header
+	Body    []byte
+	Latency time.Duration
+}
+
+// Next calls the following interceptor of the chain, or does the request if it is the last one
+type Next func(ctx context.Context, request *Request) (*Response, error)
+
+// Interceptor wraps each request made by a client, including each retry.
+// An interceptor must call next to continue with the request, or return a response or an error to cut it short.
+//
+//	func(ctx context.Context, request *rest.Request, next rest.Next) (*rest.Response, error) {
+//		request.Header.Set("X-Trace-Id", traceID(ctx))
+//		return next(ctx, request)
+//	}
+type Interceptor func(ctx context.Context, request *Request, next Next) (*Response, error)
+
+// WithInterceptors adds interceptors to the client. The first interceptor is the outermost of the chain.
+func WithInterceptors(interceptors ...Interceptor) ClientOption {
+	return func(config *clientConfig) {
+		config.interceptors = append(config.interceptors, interceptors...)
+	}
+}
+
+// LoggingInterceptor logs the method, endpoint, status and latency of every request
+func LoggingInterceptor(logger *log.Logger) Interceptor {
+	return func(ctx context.Context, request *Request, next Next) (*Response, error) {
+		start := time.Now()
+		response, err := next(ctx, request)
+		latency := time.Since(start)
+		if err != nil || response == nil {
+			logger.Printf("%s %s failed after %v: %v", request.Method, request.Endpoint, latency, err)
+			return response, err
+		}
+		logger.Printf("%s %s %d %v", request.Method, request.Endpoint, response.Status, latency)
+		return response, err
+	}
+}
+
+// HeaderInterceptor adds the given headers to every request
+func HeaderInterceptor(header http.Header) Interceptor {
+	return func(ctx context.Context, request *Request, next Next) (*Response, error) {
+		for key, values := range header {
+			for _, value := range values {
+				request.Header.Add(key, value)
+			}
+		}
+		return next(ctx, request)
+	}
+}
+
+// MetricsInterceptor calls record after every request with its outcome.
+// status is 0 if the request failed before getting a response
+func MetricsInterceptor(
+	record func(method, endpoint string, status int, latency time.Duration, err error),
+) Interceptor {
+	return func(ctx context.Context, request *Request, next Next) (*Response, error) {
+		start := time.Now()
+		response, err := next(ctx, request)
+		status := 0
+		if response != nil {
+			status = response.Status
+		}
+		record(request.Method, request.Endpoint, status, time.Since(start), err)
+		return response, err
+	}
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+)
+
+func TestInterceptorsOrderAndHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "trace" {
+			t.Errorf("missing injected header")
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	calls := []string{}
+	tracing := func(name string) Interceptor {
+		return func(ctx context.Context, request *Request, next Next) (*Response, error) {
+			calls = append(calls, name)
+			return next(ctx, request)
+		}
+	}
+	var recordedStatus int
+	var recordedEndpoint string
+	logs := new(bytes.Buffer)
+	client := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithCredentials("key", "secret"),
+		WithInterceptors(
+			tracing("first"),
+			tracing("second"),
+			HeaderInterceptor(http.Header{"X-Trace-Id": []string{"trace"}}),
+			LoggingInterceptor(log.New(logs, "", 0)),
+			MetricsInterceptor(func(method, endpoint string, status int, latency time.Duration, err error) {
+				recordedStatus = status
+				recordedEndpoint = endpoint
+			}),
+		),
+	)
+	if _, err := client.GetAllActiveSpotOrders(context.Background(), args.Symbol("EOSETH")); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(calls, ",") != "first,second" {
+		t.Fatalf("unexpected order of interceptors: %v", calls)
+	}
+	if recordedStatus != http.StatusOK || recordedEndpoint != endpointOrder {
+		t.Fatalf("unexpected metrics: %d %s", recordedStatus, recordedEndpoint)
+	}
+	if !strings.Contains(logs.String(), "GET spot/order 200") {
+		t.Fatalf("unexpected log: %s", logs.String())
+	}
+}
+
+func TestInterceptorFaultInjection(t *testing.T) {
+	injected := errors.New("injected fault")
+	client := NewClientWithOptions(
+		WithInterceptors(func(ctx context.Context, request *Request, next Next) (*Response, error) {
+			return nil, injected
+		}),
+	)
+	if _, err := client.GetCurrencies(context.Background()); !errors.Is(err, injected) {
+		t.Fatalf("expected injected fault, got %v", err)
+	}
+}
+
+func TestInterceptorShortCircuitResponse(t *testing.T) {
+	client := NewClientWithOptions(
+		WithInterceptors(func(ctx context.Context, request *Request, next Next) (*Response, error) {
+			return &Response{Status: http.StatusOK, Body: []byte(`{"full_name":"Fake"}`)}, nil
+		}),
+	)
+	result, err := client.GetCurrency(context.Background(), args.Currency("EOS"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.FullName != "Fake" {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+//go:generate go run ../internal/mockgen interfaces.go mocks.go
+
+// The interfaces below group the methods of the Client by capability, so code can
+// depend on only what it uses and tests can replace the client with the mocks of mocks.go
+
+// PublicMarketData is the market data of the public api. Requires no credentials
+type PublicMarketData interface {
+	GetCurrencies(ctx context.Context, arguments ...args.Argument) (map[string]models.Currency, error)
+	GetCurrency(ctx context.Context, arguments ...args.Argument) (*models.Currency, error)
+	GetSymbols(ctx context.Context, arguments ...args.Argument) (map[string]models.Symbol, error)
+	GetSymbol(ctx context.Context, arguments ...args.Argument) (*models.Symbol, error)
+	GetTickers(ctx context.Context, arguments ...args.Argument) (map[string]models.Ticker, error)
+	GetTickerOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.Ticker, error)
+	GetPrices(ctx context.Context, arguments ...args.Argument) (map[string]models.Price, error)
+	GetPricesHistory(ctx context.Context, arguments ...args.Argument) (map[string]models.PriceHistory, error)
+	GetTickerLastPrices(ctx context.Context, arguments ...args.Argument) (map[string]models.Price, error)
+	GetTickerLastPricesOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.Price, error)
+	GetTrades(ctx context.Context, arguments ...args.Argument) (map[string][]models.PublicTrade, error)
+	GetTradesOfSymbol(ctx context.Context, arguments ...args.Argument) ([]models.PublicTrade, error)
+	GetOrderbooks(ctx context.Context, arguments ...args.Argument) (map[string]models.OrderBook, error)
+	GetOrderBookOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.OrderBook, error)
+	GetOrderBookVolumeOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.OrderBook, error)
+	GetCandles(ctx context.Context, arguments ...args.Argument) (map[string][]models.Candle, error)
+	GetCandlesOfSymbol(ctx context.Context, arguments ...args.Argument) ([]models.Candle, error)
+	GetConvertedCandles(ctx context.Context, arguments ...args.Argument) (models.ConvertedCandles, error)
+	GetConvertedCandlesOfSymbol(ctx context.Context, arguments ...args.Argument) (models.ConvertedCandlesOfSymbol, error)
+	IterateTradesOfSymbol(ctx context.Context, arguments ...args.Argument) *Iterator[models.PublicTrade]
+	IterateCandlesOfSymbol(ctx context.Context, arguments ...args.Argument) *Iterator[models.Candle]
+}
+
+// SpotTrading manages the balances, orders and commissions of spot trading
+type SpotTrading interface {
+	GetSpotTradingBalances(ctx context.Context) ([]models.Balance, error)
+	GetSpotTradingBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error)
+	GetAllActiveSpotOrders(ctx context.Context, arguments ...args.Argument) ([]models.Order, error)
+	GetActiveSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	CreateSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	ReplaceSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	CreateSpotOrderList(ctx context.Context, arguments ...args.Argument) ([]models.Order, error)
+	CancelAllSpotOrders(ctx context.Context) ([]models.Order, error)
+	CancelSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	GetAllTradingCommissions(ctx context.Context) ([]models.TradingCommission, error)
+	GetTradingCommissionOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.TradingCommission, error)
+}
+
+// SpotTradingHistory is the history of spot orders and trades
+type SpotTradingHistory interface {
+	GetSpotOrdersHistory(ctx context.Context, arguments ...args.Argument) ([]models.Order, error)
+	GetSpotTradesHistory(ctx context.Context, arguments ...args.Argument) ([]models.Trade, error)
+	IterateSpotOrdersHistory(ctx context.Context, arguments ...args.Argument) *Iterator[models.Order]
+	IterateSpotTradesHistory(ctx context.Context, arguments ...args.Argument) *Iterator[models.Trade]
+}
+
+// WalletManagement manages the wallet balances, addresses, withdrawals, transfers and transactions
+type WalletManagement interface {
+	GetWalletBallances(ctx context.Context) ([]models.Balance, error)
+	GetWalletBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error)
+	GetDepositCryptoAddresses(ctx context.Context, arguments ...args.Argument) ([]models.CryptoAddress, error)
+	GetDepositCryptoAddressOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.CryptoAddress, error)
+	CreateDepositCryptoAddress(ctx context.Context, arguments ...args.Argument) (*models.CryptoAddress, error)
+	GetLast10DepositCryptoAddresses(ctx context.Context, arguments ...args.Argument) ([]models.CryptoAddress, error)
+	GetLast10WithdrawalCryptoAddresses(ctx context.Context, arguments ...args.Argument) ([]models.CryptoAddress, error)
+	WithdrawCryptoCommit(ctx context.Context, arguments ...args.Argument) (bool, error)
+	WithdrawCryptoRollback(ctx context.Context, arguments ...args.Argument) (bool, error)
+	GetEstimateWithdrawalFees(ctx context.Context, arguments ...args.Argument) ([]models.Fee, error)
+	GetEstimateWithdrawFee(ctx context.Context, arguments ...args.Argument) (decimal.Decimal, error)
+	GetBulkEstimateWithdrawalFees(ctx context.Context, arguments ...args.Argument) ([]models.Fee, error)
+	GetWithdrawalFeesHash(ctx context.Context) (string, error)
+	ConvertBetweenCurrencies(ctx context.Context, arguments ...args.Argument) ([]string, error)
+	CheckIfCryptoAddressBelongsToCurrentAccount(ctx context.Context, arguments ...args.Argument) (bool, error)
+	TransferBetweenWalletAndExchange(ctx context.Context, arguments ...args.Argument) (string, error)
+	TransferMoneyToAnotherUser(ctx context.Context, arguments ...args.Argument) (string, error)
+	GetTransactionHistory(ctx context.Context, arguments ...args.Argument) ([]models.Transaction, error)
+	IterateTransactionHistory(ctx context.Context, arguments ...args.Argument) *Iterator[models.Transaction]
+	GetTransaction(ctx context.Context, arguments ...args.Argument) (*models.Transaction, error)
+	CheckIfOffchainIsAvailable(ctx context.Context, arguments ...args.Argument) (bool, error)
+	GetAmountLocks(ctx context.Context, arguments ...args.Argument) ([]models.AmountLock, error)
+}
+
+// SubAccounts manages the sub-accounts of the account
+type SubAccounts interface {
+	GetSubAccounts(ctx context.Context) ([]models.SubAccount, error)
+	FreezeSubAccounts(ctx context.Context, arguments ...args.Argument) (bool, error)
+	ActivateSubAccounts(ctx context.Context, arguments ...args.Argument) (bool, error)
+	TransferFunds(ctx context.Context, arguments ...args.Argument) (bool, error)
+	TransferToSuperAccount(ctx context.Context, arguments ...args.Argument) (bool, error)
+	TransferToAnotherSubAccount(ctx context.Context, arguments ...args.Argument) (bool, error)
+	GetACLSettings(ctx context.Context, arguments ...args.Argument) ([]models.ACLSettings, error)
+	ChangeACLSettings(ctx context.Context, arguments ...args.Argument) (bool, error)
+	GetSubAccountBalances(ctx context.Context, arguments ...args.Argument) (models.SubAccountBalances, error)
+	GetSubAccountCryptoAddress(ctx context.Context, arguments ...args.Argument) (string, error)
+}
+
+var (
+	_ PublicMarketData   = (*Client)(nil)
+	_ SpotTrading        = (*Client)(nil)
+	_ SpotTradingHistory = (*Client)(nil)
+	_ WalletManagement   = (*Client)(nil)
+	_ SubAccounts        = (*Client)(nil)
+)
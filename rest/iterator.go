@@ -0,0 +1,283 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/internal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+const (
+	maxPageLimit = 1000
+	maxOffset    = 100_000
+)
+
+// Iterator walks all the records of a history endpoint, fetching a page when the previous one is consumed.
+//
+// Pages are fetched with offsets, and when the offsets run out the iterator moves the From or Till
+// bound (IDFrom or IDTill for transactions ordered by id) to the last record, skipping the records
+// of the bound already walked. Pages are requests of the client, so they go through its rate limiter.
+//
+//	iterator := client.IterateSpotTradesHistory(ctx, args.Symbol("EOSETH"))
+//	for iterator.Next() {
+//		trade := iterator.Value()
+//	}
+//	if err := iterator.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx       context.Context
+	fetch     func(ctx context.Context, arguments ...args.Argument) ([]T, error)
+	arguments []args.Argument
+	cursor    cursor[T]
+	limit     int
+	offset    int
+	maxOffset int
+	ascending bool
+	page      []T
+	lastPage  bool
+	value     T
+	// bound is the cursor value of the bound, and skip the keys of the records of the bound already walked
+	bound string
+	skip  map[string]bool
+	// last is the cursor value of the last record, and lastKeys the keys of the records of that value
+	last     string
+	lastKeys map[string]bool
+	err      error
+}
+
+// cursor tells how to bound the queries of an endpoint by a record
+type cursor[T any] struct {
+	fromArg string
+	tillArg string
+	valueOf func(T) string
+	keyOf   func(T) string
+}
+
+// IteratorOf returns an iterator of the records, ending with the error if not nil.
+// For the iterators of the mocks
+func IteratorOf[T any](records []T, err error) *Iterator[T] {
+	none := func(T) string { return "" }
+	return &Iterator[T]{
+		ctx: context.Background(),
+		fetch: func(context.Context, ...args.Argument) ([]T, error) {
+			return nil, err
+		},
+		cursor:    cursor[T]{valueOf: none, keyOf: none},
+		limit:     maxPageLimit,
+		maxOffset: maxOffset,
+		page:      records,
+		lastPage:  err == nil,
+		lastKeys:  make(map[string]bool),
+	}
+}
+
+func newIterator[T any](
+	ctx context.Context,
+	fetch func(ctx context.Context, arguments ...args.Argument) ([]T, error),
+	arguments []args.Argument,
+	cursor cursor[T],
+) *Iterator[T] {
+	iterator := &Iterator[T]{
+		ctx:       ctx,
+		fetch:     fetch,
+		arguments: arguments[:len(arguments):len(arguments)],
+		cursor:    cursor,
+		limit:     maxPageLimit,
+		maxOffset: maxOffset,
+	}
+	params, err := args.BuildParams(arguments)
+	if err != nil {
+		iterator.err = err
+		return iterator
+	}
+	if limit, ok := params[internal.ArgNameLimit].(int); ok && limit > 0 && limit < maxPageLimit {
+		iterator.limit = limit
+	}
+	if offset, ok := params[internal.ArgNameOffset].(int); ok && offset > 0 {
+		iterator.offset = offset
+	}
+	iterator.ascending = params[internal.ArgNameSort] == args.SortASC
+	return iterator
+}
+
+// Next advances to the next record, fetching a page if needed.
+// Returns false at the end of the records, or on an error returned by Err
+func (iterator *Iterator[T]) Next() bool {
+	if iterator == nil {
+		return false
+	}
+	for {
+		if iterator.err != nil {
+			return false
+		}
+		if len(iterator.page) == 0 {
+			if iterator.lastPage {
+				return false
+			}
+			iterator.fetchPage()
+			continue
+		}
+		value := iterator.page[0]
+		iterator.page = iterator.page[1:]
+		cursorValue, key := iterator.cursor.valueOf(value), iterator.cursor.keyOf(value)
+		if iterator.skip != nil {
+			if cursorValue == iterator.bound && iterator.skip[key] {
+				continue
+			}
+			iterator.skip = nil
+		}
+		if cursorValue != iterator.last {
+			iterator.last = cursorValue
+			iterator.lastKeys = make(map[string]bool)
+		}
+		iterator.lastKeys[key] = true
+		iterator.value = value
+		return true
+	}
+}
+
+// Value returns the current record
+func (iterator *Iterator[T]) Value() T {
+	return iterator.value
+}
+
+// Err returns the error that stopped the iteration, if any
+func (iterator *Iterator[T]) Err() error {
+	if iterator == nil {
+		return &models.SDKError{Message: "nil iterator, as the ones of methods not mocked"}
+	}
+	return iterator.err
+}
+
+func (iterator *Iterator[T]) fetchPage() {
+	if err := iterator.ctx.Err(); err != nil {
+		iterator.err = err
+		return
+	}
+	if iterator.offset > iterator.maxOffset {
+		iterator.moveBound()
+		if iterator.err != nil {
+			return
+		}
+	}
+	arguments := append(iterator.arguments, args.Limit(iterator.limit), args.Offset(iterator.offset))
+	if iterator.bound != "" {
+		arguments = append(arguments, iterator.boundArgument())
+	}
+	page, err := iterator.fetch(iterator.ctx, arguments...)
+	if err != nil {
+		iterator.err = err
+		return
+	}
+	iterator.page = page
+	iterator.offset += len(page)
+	iterator.lastPage = len(page) < iterator.limit
+}
+
+// moveBound bounds the queries by the last record, restarting the offsets. Fails if the bound
+// does not move, as more records than the offsets reach share the value of the bound
+func (iterator *Iterator[T]) moveBound() {
+	if iterator.last == iterator.bound {
+		iterator.err = &models.SDKError{Message: fmt.Sprintf("more than %d records at %s, the offsets can not reach past them", iterator.maxOffset, iterator.bound)}
+		return
+	}
+	iterator.bound = iterator.last
+	iterator.skip = iterator.lastKeys
+	iterator.offset = 0
+}
+
+func (iterator *Iterator[T]) boundArgument() args.Argument {
+	name := iterator.cursor.tillArg
+	if iterator.ascending {
+		name = iterator.cursor.fromArg
+	}
+	bound := iterator.bound
+	return func(params map[string]interface{}) {
+		params[name] = bound
+	}
+}
+
+func idOf(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// sortedBy returns the cursor of the records by id, or by timestamp
+func sortedBy[T any](byID bool, idArgs [2]string, id func(T) int64, timestamp func(T) string) cursor[T] {
+	key := func(record T) string { return idOf(id(record)) }
+	if byID {
+		return cursor[T]{fromArg: idArgs[0], tillArg: idArgs[1], valueOf: key, keyOf: key}
+	}
+	return cursor[T]{fromArg: internal.ArgNameFrom, tillArg: internal.ArgNameTill, valueOf: timestamp, keyOf: key}
+}
+
+var fromTill = [2]string{internal.ArgNameFrom, internal.ArgNameTill}
+
+func paramOf(arguments []args.Argument, name string) interface{} {
+	params, _ := args.BuildParams(arguments)
+	return params[name]
+}
+
+// IterateSpotOrdersHistory walks the spot orders history. Takes the arguments of GetSpotOrdersHistory,
+// where Limit is the size of the pages and Offset the start of the first one
+func (client *Client) IterateSpotOrdersHistory(ctx context.Context, arguments ...args.Argument) *Iterator[models.Order] {
+	byID := paramOf(arguments, internal.ArgNameSortBy) == args.SortByID
+	return newIterator(ctx, client.GetSpotOrdersHistory, arguments, sortedBy(byID, fromTill,
+		func(order models.Order) int64 { return order.ID },
+		func(order models.Order) string { return order.CreatedAt.String() },
+	))
+}
+
+// IterateSpotTradesHistory walks the spot trades history. Takes the arguments of GetSpotTradesHistory,
+// where Limit is the size of the pages and Offset the start of the first one
+func (client *Client) IterateSpotTradesHistory(ctx context.Context, arguments ...args.Argument) *Iterator[models.Trade] {
+	byID := paramOf(arguments, internal.ArgNameSortBy) == args.SortByID
+	return newIterator(ctx, client.GetSpotTradesHistory, arguments, sortedBy(byID, fromTill,
+		func(trade models.Trade) int64 { return trade.ID },
+		func(trade models.Trade) string { return trade.Timestamp.String() },
+	))
+}
+
+// IterateTransactionHistory walks the transaction history. Takes the arguments of GetTransactionHistory,
+// where Limit is the size of the pages and Offset the start of the first one
+func (client *Client) IterateTransactionHistory(ctx context.Context, arguments ...args.Argument) *Iterator[models.Transaction] {
+	orderBy := paramOf(arguments, internal.ArgNameOrderBy)
+	return newIterator(ctx, client.GetTransactionHistory, arguments, sortedBy(orderBy == args.OrderByID,
+		[2]string{internal.ArgNameIDFrom, internal.ArgNameIDTill},
+		func(transaction models.Transaction) int64 { return transaction.ID },
+		func(transaction models.Transaction) string {
+			switch orderBy {
+			case args.OrderByUpdateAt:
+				return transaction.UpdatedAt.String()
+			case args.OrderByLastActivityAt:
+				return transaction.LastActivityAt.String()
+			}
+			return transaction.CreatedAt.String()
+		},
+	))
+}
+
+// IterateTradesOfSymbol walks the public trades of a symbol. Takes the arguments of GetTradesOfSymbol,
+// where Limit is the size of the pages and Offset the start of the first one
+func (client *Client) IterateTradesOfSymbol(ctx context.Context, arguments ...args.Argument) *Iterator[models.PublicTrade] {
+	byID := paramOf(arguments, internal.ArgNameSortBy) == args.SortByID
+	return newIterator(ctx, client.GetTradesOfSymbol, arguments, sortedBy(byID, fromTill,
+		func(trade models.PublicTrade) int64 { return trade.ID },
+		func(trade models.PublicTrade) string { return trade.Timestamp.String() },
+	))
+}
+
+// IterateCandlesOfSymbol walks the candles of a symbol. Takes the arguments of GetCandlesOfSymbol,
+// where Limit is the size of the pages and Offset the start of the first one
+func (client *Client) IterateCandlesOfSymbol(ctx context.Context, arguments ...args.Argument) *Iterator[models.Candle] {
+	timestamp := func(candle models.Candle) string { return candle.Timestamp.String() }
+	return newIterator(ctx, client.GetCandlesOfSymbol, arguments, cursor[models.Candle]{
+		fromArg: internal.ArgNameFrom,
+		tillArg: internal.ArgNameTill,
+		valueOf: timestamp,
+		keyOf:   timestamp,
+	})
+}
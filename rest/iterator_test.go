@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/internal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// tradesOf returns a fetch of the trades with the from, till, sort, limit and offset params of the exchange
+func tradesOf(trades []models.PublicTrade, requests *[]map[string]interface{}) func(context.Context, ...args.Argument) ([]models.PublicTrade, error) {
+	return func(ctx context.Context, arguments ...args.Argument) ([]models.PublicTrade, error) {
+		params, _ := args.BuildParams(arguments)
+		*requests = append(*requests, params)
+		ascending := params[internal.ArgNameSort] == args.SortASC
+		var page []models.PublicTrade
+		for _, trade := range trades {
+			if from, ok := params[internal.ArgNameFrom].(string); ok && trade.Timestamp.String() < from {
+				continue
+			}
+			if till, ok := params[internal.ArgNameTill].(string); ok && trade.Timestamp.String() > till {
+				continue
+			}
+			page = append(page, trade)
+		}
+		sort.SliceStable(page, func(i, j int) bool {
+			if page[i].Timestamp.Equal(page[j].Timestamp.Time) {
+				return (page[i].ID < page[j].ID) == ascending
+			}
+			return page[i].Timestamp.Before(page[j].Timestamp.Time) == ascending
+		})
+		offset, limit := params[internal.ArgNameOffset].(int), params[internal.ArgNameLimit].(int)
+		if offset >= len(page) {
+			return nil, nil
+		}
+		page = page[offset:]
+		if limit < len(page) {
+			page = page[:limit]
+		}
+		return page, nil
+	}
+}
+
+func TestIteratorMovesBoundWhenOffsetsRunOut(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var trades []models.PublicTrade
+	for i := 0; i < 25; i++ {
+		// pairs of trades share a timestamp, so the bounds have repeated records
+		timestamp := models.NewTime(start.Add(time.Duration(i/2) * time.Second))
+		trades = append(trades, models.PublicTrade{ID: int64(i), Timestamp: timestamp})
+	}
+	for _, sortType := range []args.SortType{args.SortASC, args.SortDESC} {
+		var requests []map[string]interface{}
+		iterator := newIterator(context.Background(), tradesOf(trades, &requests),
+			[]args.Argument{args.Sort(sortType), args.Limit(3)},
+			sortedBy(false, fromTill,
+				func(trade models.PublicTrade) int64 { return trade.ID },
+				func(trade models.PublicTrade) string { return trade.Timestamp.String() },
+			),
+		)
+		iterator.maxOffset = 5
+		seen := make(map[int64]bool)
+		var previous *models.PublicTrade
+		for iterator.Next() {
+			trade := iterator.Value()
+			if seen[trade.ID] {
+				t.Fatalf("trade %d walked twice with %s", trade.ID, sortType)
+			}
+			seen[trade.ID] = true
+			if previous != nil && trade.Timestamp.Before(previous.Timestamp.Time) == (sortType == args.SortASC) && !trade.Timestamp.Equal(previous.Timestamp.Time) {
+				t.Fatalf("trade %d out of order with %s", trade.ID, sortType)
+			}
+			previous = &trade
+		}
+		if err := iterator.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if len(seen) != len(trades) {
+			t.Fatalf("walked %d of %d trades with %s", len(seen), len(trades), sortType)
+		}
+		bounded := false
+		for _, params := range requests {
+			if params[internal.ArgNameOffset].(int) > 5 {
+				t.Fatalf("offset over the max in %v", params)
+			}
+			_, from := params[internal.ArgNameFrom]
+			_, till := params[internal.ArgNameTill]
+			bounded = bounded || from || till
+		}
+		if !bounded {
+			t.Fatalf("expected bounded requests with %s", sortType)
+		}
+	}
+}
+
+func TestIteratorFailsWhenBoundDoesNotMove(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var trades []models.PublicTrade
+	for i := 0; i < 25; i++ {
+		// more trades share the timestamp than the offsets reach
+		trades = append(trades, models.PublicTrade{ID: int64(i), Timestamp: models.NewTime(start)})
+	}
+	var requests []map[string]interface{}
+	iterator := newIterator(context.Background(), tradesOf(trades, &requests),
+		[]args.Argument{args.Limit(3)},
+		sortedBy(false, fromTill,
+			func(trade models.PublicTrade) int64 { return trade.ID },
+			func(trade models.PublicTrade) string { return trade.Timestamp.String() },
+		),
+	)
+	iterator.maxOffset = 5
+	for iterator.Next() {
+		if len(requests) > 100 {
+			t.Fatal("the iterator keeps fetching the same records")
+		}
+	}
+	var sdkErr *models.SDKError
+	if !errors.As(iterator.Err(), &sdkErr) {
+		t.Fatalf("expected an sdk error, got %v", iterator.Err())
+	}
+}
+
+func TestIteratorStopsOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	fetch := func(ctx context.Context, arguments ...args.Argument) ([]models.Candle, error) {
+		calls++
+		cancel()
+		return []models.Candle{{Timestamp: models.NewTimeFromMillis(int64(calls))}}, nil
+	}
+	timestamp := func(candle models.Candle) string { return candle.Timestamp.String() }
+	iterator := newIterator(ctx, fetch, []args.Argument{args.Limit(1)}, cursor[models.Candle]{valueOf: timestamp, keyOf: timestamp})
+	count := 0
+	for iterator.Next() {
+		count++
+	}
+	if count != 1 || calls != 1 || !errors.Is(iterator.Err(), context.Canceled) {
+		t.Fatalf("expected one record and a canceled error, got %d records, %d calls and %v", count, calls, iterator.Err())
+	}
+}
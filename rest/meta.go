@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// headers of the exchange read into the ResponseMeta
+const (
+	HeaderRateLimitLimit     = "X-Ratelimit-Limit"
+	HeaderRateLimitRemaining = "X-Ratelimit-Remaining"
+	HeaderRequestID          = "X-Request-Id"
+)
+
+// ResponseMeta is the metadata of the response to a call, as the status and
+// headers that are not part of the returned model.
+type ResponseMeta struct {
+	// Status is the http status of the last attempt. 0 if no response was received
+	Status int
+	// Header are the headers of the last attempt
+	Header http.Header
+	// RequestID identifies the request on the exchange, from the request id header or the error response
+	RequestID string
+	// Latency is the time of the last attempt
+	Latency time.Duration
+	// Duration is the time of the whole call, including retries and rate limit waits
+	Duration time.Duration
+	// Attempts is the number of requests sent
+	Attempts int
+}
+
+// RateLimitLimit returns the number of requests allowed in the rate limit period, if informed by the exchange
+func (meta *ResponseMeta) RateLimitLimit() (int, bool) {
+	return meta.intHeader(HeaderRateLimitLimit)
+}
+
+// RateLimitRemaining returns the number of requests remaining in the rate limit period, if informed by the exchange
+func (meta *ResponseMeta) RateLimitRemaining() (int, bool) {
+	return meta.intHeader(HeaderRateLimitRemaining)
+}
+
+func (meta *ResponseMeta) intHeader(key string) (int, bool) {
+	value, err := strconv.Atoi(meta.Header.Get(key))
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+type metaKey struct{}
+
+// ContextWithMeta returns a copy of the context that makes the calls done with it
+// fill the given meta with the metadata of their response, even if they fail.
+// Use a meta per call, as concurrent calls with the same context overwrite each other.
+//
+//	var meta rest.ResponseMeta
+//	order, err := client.CreateSpotOrder(rest.ContextWithMeta(ctx, &meta), ...)
+//	fmt.Println(meta.RequestID, meta.Latency)
+func ContextWithMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, metaKey{}, meta)
+}
+
+func recordMeta(ctx context.Context, response *Response, attempts int, duration time.Duration) {
+	meta, ok := ctx.Value(metaKey{}).(*ResponseMeta)
+	if !ok || meta == nil {
+		return
+	}
+	*meta = ResponseMeta{Attempts: attempts, Duration: duration}
+	if response == nil {
+		return
+	}
+	meta.Status = response.Status
+	meta.Header = response.Header
+	meta.Latency = response.Latency
+	meta.RequestID = response.Header.Get(HeaderRequestID)
+	if meta.RequestID == "" && response.Status >= http.StatusBadRequest {
+		body := struct {
+			RequestID string `json:"request_id"`
+		}{}
+		json.Unmarshal(response.Body, &body)
+		meta.RequestID = body.RequestID
+	}
+}
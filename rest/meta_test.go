@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+)
+
+func TestContextWithMeta(t *testing.T) {
+	server, _ := newFailingServer(1, http.StatusServiceUnavailable)
+	defer server.Close()
+	client := newRetryingClient(server.URL)
+	var meta ResponseMeta
+	if _, err := client.GetActiveSpotOrder(ContextWithMeta(context.Background(), &meta), args.ClientOrderID("abc")); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Status != http.StatusOK || meta.Attempts != 2 || meta.Latency <= 0 || meta.Duration < meta.Latency {
+		t.Fatalf("unexpected meta %+v", meta)
+	}
+}
+
+func TestMetaOfFailedCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderRateLimitRemaining, "7")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"request_id":"req-1","error":{"code":20002,"message":"Order not found"}}`))
+	}))
+	defer server.Close()
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithCredentials("key", "secret"))
+	var meta ResponseMeta
+	if _, err := client.GetActiveSpotOrder(ContextWithMeta(context.Background(), &meta), args.ClientOrderID("abc")); err == nil {
+		t.Fatal("should fail")
+	}
+	if meta.RequestID != "req-1" || meta.Status != http.StatusBadRequest {
+		t.Fatalf("unexpected meta %+v", meta)
+	}
+	if remaining, ok := meta.RateLimitRemaining(); !ok || remaining != 7 {
+		t.Fatalf("expected 7 remaining requests, got %d", remaining)
+	}
+	if _, ok := meta.RateLimitLimit(); ok {
+		t.Fatal("limit was not informed")
+	}
+}
@@ -0,0 +1,730 @@
+// Code generated by mockgen from interfaces.go. DO NOT EDIT.
+
+package rest
+
+import (
+	"context"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+func errNotMocked(method string) error {
+	return &models.SDKError{Message: method + " is not mocked"}
+}
+
+// MockPublicMarketData is a mock of PublicMarketData. Each method calls the field of its name with the Func suffix,
+// and fails as not mocked if the field is nil
+type MockPublicMarketData struct {
+	GetCurrenciesFunc               func(ctx context.Context, arguments ...args.Argument) (map[string]models.Currency, error)
+	GetCurrencyFunc                 func(ctx context.Context, arguments ...args.Argument) (*models.Currency, error)
+	GetSymbolsFunc                  func(ctx context.Context, arguments ...args.Argument) (map[string]models.Symbol, error)
+	GetSymbolFunc                   func(ctx context.Context, arguments ...args.Argument) (*models.Symbol, error)
+	GetTickersFunc                  func(ctx context.Context, arguments ...args.Argument) (map[string]models.Ticker, error)
+	GetTickerOfSymbolFunc           func(ctx context.Context, arguments ...args.Argument) (*models.Ticker, error)
+	GetPricesFunc                   func(ctx context.Context, arguments ...args.Argument) (map[string]models.Price, error)
+	GetPricesHistoryFunc            func(ctx context.Context, arguments ...args.Argument) (map[string]models.PriceHistory, error)
+	GetTickerLastPricesFunc         func(ctx context.Context, arguments ...args.Argument) (map[string]models.Price, error)
+	GetTickerLastPricesOfSymbolFunc func(ctx context.Context, arguments ...args.Argument) (*models.Price, error)
+	GetTradesFunc                   func(ctx context.Context, arguments ...args.Argument) (map[string][]models.PublicTrade, error)
+	GetTradesOfSymbolFunc           func(ctx context.Context, arguments ...args.Argument) ([]models.PublicTrade, error)
+	GetOrderbooksFunc               func(ctx context.Context, arguments ...args.Argument) (map[string]models.OrderBook, error)
+	GetOrderBookOfSymbolFunc        func(ctx context.Context, arguments ...args.Argument) (*models.OrderBook, error)
+	GetOrderBookVolumeOfSymbolFunc  func(ctx context.Context, arguments ...args.Argument) (*models.OrderBook, error)
+	GetCandlesFunc                  func(ctx context.Context, arguments ...args.Argument) (map[string][]models.Candle, error)
+	GetCandlesOfSymbolFunc          func(ctx context.Context, arguments ...args.Argument) ([]models.Candle, error)
+	GetConvertedCandlesFunc         func(ctx context.Context, arguments ...args.Argument) (models.ConvertedCandles, error)
+	GetConvertedCandlesOfSymbolFunc func(ctx context.Context, arguments ...args.Argument) (models.ConvertedCandlesOfSymbol, error)
+	IterateTradesOfSymbolFunc       func(ctx context.Context, arguments ...args.Argument) *Iterator[models.PublicTrade]
+	IterateCandlesOfSymbolFunc      func(ctx context.Context, arguments ...args.Argument) *Iterator[models.Candle]
+}
+
+// GetCurrencies calls GetCurrenciesFunc
+func (mock *MockPublicMarketData) GetCurrencies(ctx context.Context, arguments ...args.Argument) (map[string]models.Currency, error) {
+	if mock.GetCurrenciesFunc == nil {
+		var r0 map[string]models.Currency
+		return r0, errNotMocked("MockPublicMarketData.GetCurrencies")
+	}
+	return mock.GetCurrenciesFunc(ctx, arguments...)
+}
+
+// GetCurrency calls GetCurrencyFunc
+func (mock *MockPublicMarketData) GetCurrency(ctx context.Context, arguments ...args.Argument) (*models.Currency, error) {
+	if mock.GetCurrencyFunc == nil {
+		var r0 *models.Currency
+		return r0, errNotMocked("MockPublicMarketData.GetCurrency")
+	}
+	return mock.GetCurrencyFunc(ctx, arguments...)
+}
+
+// GetSymbols calls GetSymbolsFunc
+func (mock *MockPublicMarketData) GetSymbols(ctx context.Context, arguments ...args.Argument) (map[string]models.Symbol, error) {
+	if mock.GetSymbolsFunc == nil {
+		var r0 map[string]models.Symbol
+		return r0, errNotMocked("MockPublicMarketData.GetSymbols")
+	}
+	return mock.GetSymbolsFunc(ctx, arguments...)
+}
+
+// GetSymbol calls GetSymbolFunc
+func (mock *MockPublicMarketData) GetSymbol(ctx context.Context, arguments ...args.Argument) (*models.Symbol, error) {
+	if mock.GetSymbolFunc == nil {
+		var r0 *models.Symbol
+		return r0, errNotMocked("MockPublicMarketData.GetSymbol")
+	}
+	return mock.GetSymbolFunc(ctx, arguments...)
+}
+
+// GetTickers calls GetTickersFunc
+func (mock *MockPublicMarketData) GetTickers(ctx context.Context, arguments ...args.Argument) (map[string]models.Ticker, error) {
+	if mock.GetTickersFunc == nil {
+		var r0 map[string]models.Ticker
+		return r0, errNotMocked("MockPublicMarketData.GetTickers")
+	}
+	return mock.GetTickersFunc(ctx, arguments...)
+}
+
+// GetTickerOfSymbol calls GetTickerOfSymbolFunc
+func (mock *MockPublicMarketData) GetTickerOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.Ticker, error) {
+	if mock.GetTickerOfSymbolFunc == nil {
+		var r0 *models.Ticker
+		return r0, errNotMocked("MockPublicMarketData.GetTickerOfSymbol")
+	}
+	return mock.GetTickerOfSymbolFunc(ctx, arguments...)
+}
+
+// GetPrices calls GetPricesFunc
+func (mock *MockPublicMarketData) GetPrices(ctx context.Context, arguments ...args.Argument) (map[string]models.Price, error) {
+	if mock.GetPricesFunc == nil {
+		var r0 map[string]models.Price
+		return r0, errNotMocked("MockPublicMarketData.GetPrices")
+	}
+	return mock.GetPricesFunc(ctx, arguments...)
+}
+
+// GetPricesHistory calls GetPricesHistoryFunc
+func (mock *MockPublicMarketData) GetPricesHistory(ctx context.Context, arguments ...args.Argument) (map[string]models.PriceHistory, error) {
+	if mock.GetPricesHistoryFunc == nil {
+		var r0 map[string]models.PriceHistory
+		return r0, errNotMocked("MockPublicMarketData.GetPricesHistory")
+	}
+	return mock.GetPricesHistoryFunc(ctx, arguments...)
+}
+
+// GetTickerLastPrices calls GetTickerLastPricesFunc
+func (mock *MockPublicMarketData) GetTickerLastPrices(ctx context.Context, arguments ...args.Argument) (map[string]models.Price, error) {
+	if mock.GetTickerLastPricesFunc == nil {
+		var r0 map[string]models.Price
+		return r0, errNotMocked("MockPublicMarketData.GetTickerLastPrices")
+	}
+	return mock.GetTickerLastPricesFunc(ctx, arguments...)
+}
+
+// GetTickerLastPricesOfSymbol calls GetTickerLastPricesOfSymbolFunc
+func (mock *MockPublicMarketData) GetTickerLastPricesOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.Price, error) {
+	if mock.GetTickerLastPricesOfSymbolFunc == nil {
+		var r0 *models.Price
+		return r0, errNotMocked("MockPublicMarketData.GetTickerLastPricesOfSymbol")
+	}
+	return mock.GetTickerLastPricesOfSymbolFunc(ctx, arguments...)
+}
+
+// GetTrades calls GetTradesFunc
+func (mock *MockPublicMarketData) GetTrades(ctx context.Context, arguments ...args.Argument) (map[string][]models.PublicTrade, error) {
+	if mock.GetTradesFunc == nil {
+		var r0 map[string][]models.PublicTrade
+		return r0, errNotMocked("MockPublicMarketData.GetTrades")
+	}
+	return mock.GetTradesFunc(ctx, arguments...)
+}
+
+// GetTradesOfSymbol calls GetTradesOfSymbolFunc
+func (mock *MockPublicMarketData) GetTradesOfSymbol(ctx context.Context, arguments ...args.Argument) ([]models.PublicTrade, error) {
+	if mock.GetTradesOfSymbolFunc == nil {
+		var r0 []models.PublicTrade
+		return r0, errNotMocked("MockPublicMarketData.GetTradesOfSymbol")
+	}
+	return mock.GetTradesOfSymbolFunc(ctx, arguments...)
+}
+
+// GetOrderbooks calls GetOrderbooksFunc
+func (mock *MockPublicMarketData) GetOrderbooks(ctx context.Context, arguments ...args.Argument) (map[string]models.OrderBook, error) {
+	if mock.GetOrderbooksFunc == nil {
+		var r0 map[string]models.OrderBook
+		return r0, errNotMocked("MockPublicMarketData.GetOrderbooks")
+	}
+	return mock.GetOrderbooksFunc(ctx, arguments...)
+}
+
+// GetOrderBookOfSymbol calls GetOrderBookOfSymbolFunc
+func (mock *MockPublicMarketData) GetOrderBookOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.OrderBook, error) {
+	if mock.GetOrderBookOfSymbolFunc == nil {
+		var r0 *models.OrderBook
+		return r0, errNotMocked("MockPublicMarketData.GetOrderBookOfSymbol")
+	}
+	return mock.GetOrderBookOfSymbolFunc(ctx, arguments...)
+}
+
+// GetOrderBookVolumeOfSymbol calls GetOrderBookVolumeOfSymbolFunc
+func (mock *MockPublicMarketData) GetOrderBookVolumeOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.OrderBook, error) {
+	if mock.GetOrderBookVolumeOfSymbolFunc == nil {
+		var r0 *models.OrderBook
+		return r0, errNotMocked("MockPublicMarketData.GetOrderBookVolumeOfSymbol")
+	}
+	return mock.GetOrderBookVolumeOfSymbolFunc(ctx, arguments...)
+}
+
+// GetCandles calls GetCandlesFunc
+func (mock *MockPublicMarketData) GetCandles(ctx context.Context, arguments ...args.Argument) (map[string][]models.Candle, error) {
+	if mock.GetCandlesFunc == nil {
+		var r0 map[string][]models.Candle
+		return r0, errNotMocked("MockPublicMarketData.GetCandles")
+	}
+	return mock.GetCandlesFunc(ctx, arguments...)
+}
+
+// GetCandlesOfSymbol calls GetCandlesOfSymbolFunc
+func (mock *MockPublicMarketData) GetCandlesOfSymbol(ctx context.Context, arguments ...args.Argument) ([]models.Candle, error) {
+	if mock.GetCandlesOfSymbolFunc == nil {
+		var r0 []models.Candle
+		return r0, errNotMocked("MockPublicMarketData.GetCandlesOfSymbol")
+	}
+	return mock.GetCandlesOfSymbolFunc(ctx, arguments...)
+}
+
+// GetConvertedCandles calls GetConvertedCandlesFunc
+func (mock *MockPublicMarketData) GetConvertedCandles(ctx context.Context, arguments ...args.Argument) (models.ConvertedCandles, error) {
+	if mock.GetConvertedCandlesFunc == nil {
+		var r0 models.ConvertedCandles
+		return r0, errNotMocked("MockPublicMarketData.GetConvertedCandles")
+	}
+	return mock.GetConvertedCandlesFunc(ctx, arguments...)
+}
+
+// GetConvertedCandlesOfSymbol calls GetConvertedCandlesOfSymbolFunc
+func (mock *MockPublicMarketData) GetConvertedCandlesOfSymbol(ctx context.Context, arguments ...args.Argument) (models.ConvertedCandlesOfSymbol, error) {
+	if mock.GetConvertedCandlesOfSymbolFunc == nil {
+		var r0 models.ConvertedCandlesOfSymbol
+		return r0, errNotMocked("MockPublicMarketData.GetConvertedCandlesOfSymbol")
+	}
+	return mock.GetConvertedCandlesOfSymbolFunc(ctx, arguments...)
+}
+
+// IterateTradesOfSymbol calls IterateTradesOfSymbolFunc
+func (mock *MockPublicMarketData) IterateTradesOfSymbol(ctx context.Context, arguments ...args.Argument) *Iterator[models.PublicTrade] {
+	if mock.IterateTradesOfSymbolFunc == nil {
+		var r0 *Iterator[models.PublicTrade]
+		return r0
+	}
+	return mock.IterateTradesOfSymbolFunc(ctx, arguments...)
+}
+
+// IterateCandlesOfSymbol calls IterateCandlesOfSymbolFunc
+func (mock *MockPublicMarketData) IterateCandlesOfSymbol(ctx context.Context, arguments ...args.Argument) *Iterator[models.Candle] {
+	if mock.IterateCandlesOfSymbolFunc == nil {
+		var r0 *Iterator[models.Candle]
+		return r0
+	}
+	return mock.IterateCandlesOfSymbolFunc(ctx, arguments...)
+}
+
+var _ PublicMarketData = (*MockPublicMarketData)(nil)
+
+// MockSpotTrading is a mock of SpotTrading. Each method calls the field of its name with the Func suffix,
+// and fails as not mocked if the field is nil
+type MockSpotTrading struct {
+	GetSpotTradingBalancesFunc          func(ctx context.Context) ([]models.Balance, error)
+	GetSpotTradingBalanceOfCurrencyFunc func(ctx context.Context, arguments ...args.Argument) (*models.Balance, error)
+	GetAllActiveSpotOrdersFunc          func(ctx context.Context, arguments ...args.Argument) ([]models.Order, error)
+	GetActiveSpotOrderFunc              func(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	CreateSpotOrderFunc                 func(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	ReplaceSpotOrderFunc                func(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	CreateSpotOrderListFunc             func(ctx context.Context, arguments ...args.Argument) ([]models.Order, error)
+	CancelAllSpotOrdersFunc             func(ctx context.Context) ([]models.Order, error)
+	CancelSpotOrderFunc                 func(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	GetAllTradingCommissionsFunc        func(ctx context.Context) ([]models.TradingCommission, error)
+	GetTradingCommissionOfSymbolFunc    func(ctx context.Context, arguments ...args.Argument) (*models.TradingCommission, error)
+}
+
+// GetSpotTradingBalances calls GetSpotTradingBalancesFunc
+func (mock *MockSpotTrading) GetSpotTradingBalances(ctx context.Context) ([]models.Balance, error) {
+	if mock.GetSpotTradingBalancesFunc == nil {
+		var r0 []models.Balance
+		return r0, errNotMocked("MockSpotTrading.GetSpotTradingBalances")
+	}
+	return mock.GetSpotTradingBalancesFunc(ctx)
+}
+
+// GetSpotTradingBalanceOfCurrency calls GetSpotTradingBalanceOfCurrencyFunc
+func (mock *MockSpotTrading) GetSpotTradingBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error) {
+	if mock.GetSpotTradingBalanceOfCurrencyFunc == nil {
+		var r0 *models.Balance
+		return r0, errNotMocked("MockSpotTrading.GetSpotTradingBalanceOfCurrency")
+	}
+	return mock.GetSpotTradingBalanceOfCurrencyFunc(ctx, arguments...)
+}
+
+// GetAllActiveSpotOrders calls GetAllActiveSpotOrdersFunc
+func (mock *MockSpotTrading) GetAllActiveSpotOrders(ctx context.Context, arguments ...args.Argument) ([]models.Order, error) {
+	if mock.GetAllActiveSpotOrdersFunc == nil {
+		var r0 []models.Order
+		return r0, errNotMocked("MockSpotTrading.GetAllActiveSpotOrders")
+	}
+	return mock.GetAllActiveSpotOrdersFunc(ctx, arguments...)
+}
+
+// GetActiveSpotOrder calls GetActiveSpotOrderFunc
+func (mock *MockSpotTrading) GetActiveSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	if mock.GetActiveSpotOrderFunc == nil {
+		var r0 *models.Order
+		return r0, errNotMocked("MockSpotTrading.GetActiveSpotOrder")
+	}
+	return mock.GetActiveSpotOrderFunc(ctx, arguments...)
+}
+
+// CreateSpotOrder calls CreateSpotOrderFunc
+func (mock *MockSpotTrading) CreateSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	if mock.CreateSpotOrderFunc == nil {
+		var r0 *models.Order
+		return r0, errNotMocked("MockSpotTrading.CreateSpotOrder")
+	}
+	return mock.CreateSpotOrderFunc(ctx, arguments...)
+}
+
+// ReplaceSpotOrder calls ReplaceSpotOrderFunc
+func (mock *MockSpotTrading) ReplaceSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	if mock.ReplaceSpotOrderFunc == nil {
+		var r0 *models.Order
+		return r0, errNotMocked("MockSpotTrading.ReplaceSpotOrder")
+	}
+	return mock.ReplaceSpotOrderFunc(ctx, arguments...)
+}
+
+// CreateSpotOrderList calls CreateSpotOrderListFunc
+func (mock *MockSpotTrading) CreateSpotOrderList(ctx context.Context, arguments ...args.Argument) ([]models.Order, error) {
+	if mock.CreateSpotOrderListFunc == nil {
+		var r0 []models.Order
+		return r0, errNotMocked("MockSpotTrading.CreateSpotOrderList")
+	}
+	return mock.CreateSpotOrderListFunc(ctx, arguments...)
+}
+
+// CancelAllSpotOrders calls CancelAllSpotOrdersFunc
+func (mock *MockSpotTrading) CancelAllSpotOrders(ctx context.Context) ([]models.Order, error) {
+	if mock.CancelAllSpotOrdersFunc == nil {
+		var r0 []models.Order
+		return r0, errNotMocked("MockSpotTrading.CancelAllSpotOrders")
+	}
+	return mock.CancelAllSpotOrdersFunc(ctx)
+}
+
+// CancelSpotOrder calls CancelSpotOrderFunc
+func (mock *MockSpotTrading) CancelSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	if mock.CancelSpotOrderFunc == nil {
+		var r0 *models.Order
+		return r0, errNotMocked("MockSpotTrading.CancelSpotOrder")
+	}
+	return mock.CancelSpotOrderFunc(ctx, arguments...)
+}
+
+// GetAllTradingCommissions calls GetAllTradingCommissionsFunc
+func (mock *MockSpotTrading) GetAllTradingCommissions(ctx context.Context) ([]models.TradingCommission, error) {
+	if mock.GetAllTradingCommissionsFunc == nil {
+		var r0 []models.TradingCommission
+		return r0, errNotMocked("MockSpotTrading.GetAllTradingCommissions")
+	}
+	return mock.GetAllTradingCommissionsFunc(ctx)
+}
+
+// GetTradingCommissionOfSymbol calls GetTradingCommissionOfSymbolFunc
+func (mock *MockSpotTrading) GetTradingCommissionOfSymbol(ctx context.Context, arguments ...args.Argument) (*models.TradingCommission, error) {
+	if mock.GetTradingCommissionOfSymbolFunc == nil {
+		var r0 *models.TradingCommission
+		return r0, errNotMocked("MockSpotTrading.GetTradingCommissionOfSymbol")
+	}
+	return mock.GetTradingCommissionOfSymbolFunc(ctx, arguments...)
+}
+
+var _ SpotTrading = (*MockSpotTrading)(nil)
+
+// MockSpotTradingHistory is a mock of SpotTradingHistory. Each method calls the field of its name with the Func suffix,
+// and fails as not mocked if the field is nil
+type MockSpotTradingHistory struct {
+	GetSpotOrdersHistoryFunc     func(ctx context.Context, arguments ...args.Argument) ([]models.Order, error)
+	GetSpotTradesHistoryFunc     func(ctx context.Context, arguments ...args.Argument) ([]models.Trade, error)
+	IterateSpotOrdersHistoryFunc func(ctx context.Context, arguments ...args.Argument) *Iterator[models.Order]
+	IterateSpotTradesHistoryFunc func(ctx context.Context, arguments ...args.Argument) *Iterator[models.Trade]
+}
+
+// GetSpotOrdersHistory calls GetSpotOrdersHistoryFunc
+func (mock *MockSpotTradingHistory) GetSpotOrdersHistory(ctx context.Context, arguments ...args.Argument) ([]models.Order, error) {
+	if mock.GetSpotOrdersHistoryFunc == nil {
+		var r0 []models.Order
+		return r0, errNotMocked("MockSpotTradingHistory.GetSpotOrdersHistory")
+	}
+	return mock.GetSpotOrdersHistoryFunc(ctx, arguments...)
+}
+
+// GetSpotTradesHistory calls GetSpotTradesHistoryFunc
+func (mock *MockSpotTradingHistory) GetSpotTradesHistory(ctx context.Context, arguments ...args.Argument) ([]models.Trade, error) {
+	if mock.GetSpotTradesHistoryFunc == nil {
+		var r0 []models.Trade
+		return r0, errNotMocked("MockSpotTradingHistory.GetSpotTradesHistory")
+	}
+	return mock.GetSpotTradesHistoryFunc(ctx, arguments...)
+}
+
+// IterateSpotOrdersHistory calls IterateSpotOrdersHistoryFunc
+func (mock *MockSpotTradingHistory) IterateSpotOrdersHistory(ctx context.Context, arguments ...args.Argument) *Iterator[models.Order] {
+	if mock.IterateSpotOrdersHistoryFunc == nil {
+		var r0 *Iterator[models.Order]
+		return r0
+	}
+	return mock.IterateSpotOrdersHistoryFunc(ctx, arguments...)
+}
+
+// IterateSpotTradesHistory calls IterateSpotTradesHistoryFunc
+func (mock *MockSpotTradingHistory) IterateSpotTradesHistory(ctx context.Context, arguments ...args.Argument) *Iterator[models.Trade] {
+	if mock.IterateSpotTradesHistoryFunc == nil {
+		var r0 *Iterator[models.Trade]
+		return r0
+	}
+	return mock.IterateSpotTradesHistoryFunc(ctx, arguments...)
+}
+
+var _ SpotTradingHistory = (*MockSpotTradingHistory)(nil)
+
+// MockWalletManagement is a mock of WalletManagement. Each method calls the field of its name with the Func suffix,
+// and fails as not mocked if the field is nil
+type MockWalletManagement struct {
+	GetWalletBallancesFunc                          func(ctx context.Context) ([]models.Balance, error)
+	GetWalletBalanceOfCurrencyFunc                  func(ctx context.Context, arguments ...args.Argument) (*models.Balance, error)
+	GetDepositCryptoAddressesFunc                   func(ctx context.Context, arguments ...args.Argument) ([]models.CryptoAddress, error)
+	GetDepositCryptoAddressOfCurrencyFunc           func(ctx context.Context, arguments ...args.Argument) (*models.CryptoAddress, error)
+	CreateDepositCryptoAddressFunc                  func(ctx context.Context, arguments ...args.Argument) (*models.CryptoAddress, error)
+	GetLast10DepositCryptoAddressesFunc             func(ctx context.Context, arguments ...args.Argument) ([]models.CryptoAddress, error)
+	GetLast10WithdrawalCryptoAddressesFunc          func(ctx context.Context, arguments ...args.Argument) ([]models.CryptoAddress, error)
+	WithdrawCryptoCommitFunc                        func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	WithdrawCryptoRollbackFunc                      func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	GetEstimateWithdrawalFeesFunc                   func(ctx context.Context, arguments ...args.Argument) ([]models.Fee, error)
+	GetEstimateWithdrawFeeFunc                      func(ctx context.Context, arguments ...args.Argument) (decimal.Decimal, error)
+	GetBulkEstimateWithdrawalFeesFunc               func(ctx context.Context, arguments ...args.Argument) ([]models.Fee, error)
+	GetWithdrawalFeesHashFunc                       func(ctx context.Context) (string, error)
+	ConvertBetweenCurrenciesFunc                    func(ctx context.Context, arguments ...args.Argument) ([]string, error)
+	CheckIfCryptoAddressBelongsToCurrentAccountFunc func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	TransferBetweenWalletAndExchangeFunc            func(ctx context.Context, arguments ...args.Argument) (string, error)
+	TransferMoneyToAnotherUserFunc                  func(ctx context.Context, arguments ...args.Argument) (string, error)
+	GetTransactionHistoryFunc                       func(ctx context.Context, arguments ...args.Argument) ([]models.Transaction, error)
+	IterateTransactionHistoryFunc                   func(ctx context.Context, arguments ...args.Argument) *Iterator[models.Transaction]
+	GetTransactionFunc                              func(ctx context.Context, arguments ...args.Argument) (*models.Transaction, error)
+	CheckIfOffchainIsAvailableFunc                  func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	GetAmountLocksFunc                              func(ctx context.Context, arguments ...args.Argument) ([]models.AmountLock, error)
+}
+
+// GetWalletBallances calls GetWalletBallancesFunc
+func (mock *MockWalletManagement) GetWalletBallances(ctx context.Context) ([]models.Balance, error) {
+	if mock.GetWalletBallancesFunc == nil {
+		var r0 []models.Balance
+		return r0, errNotMocked("MockWalletManagement.GetWalletBallances")
+	}
+	return mock.GetWalletBallancesFunc(ctx)
+}
+
+// GetWalletBalanceOfCurrency calls GetWalletBalanceOfCurrencyFunc
+func (mock *MockWalletManagement) GetWalletBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error) {
+	if mock.GetWalletBalanceOfCurrencyFunc == nil {
+		var r0 *models.Balance
+		return r0, errNotMocked("MockWalletManagement.GetWalletBalanceOfCurrency")
+	}
+	return mock.GetWalletBalanceOfCurrencyFunc(ctx, arguments...)
+}
+
+// GetDepositCryptoAddresses calls GetDepositCryptoAddressesFunc
+func (mock *MockWalletManagement) GetDepositCryptoAddresses(ctx context.Context, arguments ...args.Argument) ([]models.CryptoAddress, error) {
+	if mock.GetDepositCryptoAddressesFunc == nil {
+		var r0 []models.CryptoAddress
+		return r0, errNotMocked("MockWalletManagement.GetDepositCryptoAddresses")
+	}
+	return mock.GetDepositCryptoAddressesFunc(ctx, arguments...)
+}
+
+// GetDepositCryptoAddressOfCurrency calls GetDepositCryptoAddressOfCurrencyFunc
+func (mock *MockWalletManagement) GetDepositCryptoAddressOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.CryptoAddress, error) {
+	if mock.GetDepositCryptoAddressOfCurrencyFunc == nil {
+		var r0 *models.CryptoAddress
+		return r0, errNotMocked("MockWalletManagement.GetDepositCryptoAddressOfCurrency")
+	}
+	return mock.GetDepositCryptoAddressOfCurrencyFunc(ctx, arguments...)
+}
+
+// CreateDepositCryptoAddress calls CreateDepositCryptoAddressFunc
+func (mock *MockWalletManagement) CreateDepositCryptoAddress(ctx context.Context, arguments ...args.Argument) (*models.CryptoAddress, error) {
+	if mock.CreateDepositCryptoAddressFunc == nil {
+		var r0 *models.CryptoAddress
+		return r0, errNotMocked("MockWalletManagement.CreateDepositCryptoAddress")
+	}
+	return mock.CreateDepositCryptoAddressFunc(ctx, arguments...)
+}
+
+// GetLast10DepositCryptoAddresses calls GetLast10DepositCryptoAddressesFunc
+func (mock *MockWalletManagement) GetLast10DepositCryptoAddresses(ctx context.Context, arguments ...args.Argument) ([]models.CryptoAddress, error) {
+	if mock.GetLast10DepositCryptoAddressesFunc == nil {
+		var r0 []models.CryptoAddress
+		return r0, errNotMocked("MockWalletManagement.GetLast10DepositCryptoAddresses")
+	}
+	return mock.GetLast10DepositCryptoAddressesFunc(ctx, arguments...)
+}
+
+// GetLast10WithdrawalCryptoAddresses calls GetLast10WithdrawalCryptoAddressesFunc
+func (mock *MockWalletManagement) GetLast10WithdrawalCryptoAddresses(ctx context.Context, arguments ...args.Argument) ([]models.CryptoAddress, error) {
+	if mock.GetLast10WithdrawalCryptoAddressesFunc == nil {
+		var r0 []models.CryptoAddress
+		return r0, errNotMocked("MockWalletManagement.GetLast10WithdrawalCryptoAddresses")
+	}
+	return mock.GetLast10WithdrawalCryptoAddressesFunc(ctx, arguments...)
+}
+
+// WithdrawCryptoCommit calls WithdrawCryptoCommitFunc
+func (mock *MockWalletManagement) WithdrawCryptoCommit(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.WithdrawCryptoCommitFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockWalletManagement.WithdrawCryptoCommit")
+	}
+	return mock.WithdrawCryptoCommitFunc(ctx, arguments...)
+}
+
+// WithdrawCryptoRollback calls WithdrawCryptoRollbackFunc
+func (mock *MockWalletManagement) WithdrawCryptoRollback(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.WithdrawCryptoRollbackFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockWalletManagement.WithdrawCryptoRollback")
+	}
+	return mock.WithdrawCryptoRollbackFunc(ctx, arguments...)
+}
+
+// GetEstimateWithdrawalFees calls GetEstimateWithdrawalFeesFunc
+func (mock *MockWalletManagement) GetEstimateWithdrawalFees(ctx context.Context, arguments ...args.Argument) ([]models.Fee, error) {
+	if mock.GetEstimateWithdrawalFeesFunc == nil {
+		var r0 []models.Fee
+		return r0, errNotMocked("MockWalletManagement.GetEstimateWithdrawalFees")
+	}
+	return mock.GetEstimateWithdrawalFeesFunc(ctx, arguments...)
+}
+
+// GetEstimateWithdrawFee calls GetEstimateWithdrawFeeFunc
+func (mock *MockWalletManagement) GetEstimateWithdrawFee(ctx context.Context, arguments ...args.Argument) (decimal.Decimal, error) {
+	if mock.GetEstimateWithdrawFeeFunc == nil {
+		var r0 decimal.Decimal
+		return r0, errNotMocked("MockWalletManagement.GetEstimateWithdrawFee")
+	}
+	return mock.GetEstimateWithdrawFeeFunc(ctx, arguments...)
+}
+
+// GetBulkEstimateWithdrawalFees calls GetBulkEstimateWithdrawalFeesFunc
+func (mock *MockWalletManagement) GetBulkEstimateWithdrawalFees(ctx context.Context, arguments ...args.Argument) ([]models.Fee, error) {
+	if mock.GetBulkEstimateWithdrawalFeesFunc == nil {
+		var r0 []models.Fee
+		return r0, errNotMocked("MockWalletManagement.GetBulkEstimateWithdrawalFees")
+	}
+	return mock.GetBulkEstimateWithdrawalFeesFunc(ctx, arguments...)
+}
+
+// GetWithdrawalFeesHash calls GetWithdrawalFeesHashFunc
+func (mock *MockWalletManagement) GetWithdrawalFeesHash(ctx context.Context) (string, error) {
+	if mock.GetWithdrawalFeesHashFunc == nil {
+		var r0 string
+		return r0, errNotMocked("MockWalletManagement.GetWithdrawalFeesHash")
+	}
+	return mock.GetWithdrawalFeesHashFunc(ctx)
+}
+
+// ConvertBetweenCurrencies calls ConvertBetweenCurrenciesFunc
+func (mock *MockWalletManagement) ConvertBetweenCurrencies(ctx context.Context, arguments ...args.Argument) ([]string, error) {
+	if mock.ConvertBetweenCurrenciesFunc == nil {
+		var r0 []string
+		return r0, errNotMocked("MockWalletManagement.ConvertBetweenCurrencies")
+	}
+	return mock.ConvertBetweenCurrenciesFunc(ctx, arguments...)
+}
+
+// CheckIfCryptoAddressBelongsToCurrentAccount calls CheckIfCryptoAddressBelongsToCurrentAccountFunc
+func (mock *MockWalletManagement) CheckIfCryptoAddressBelongsToCurrentAccount(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.CheckIfCryptoAddressBelongsToCurrentAccountFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockWalletManagement.CheckIfCryptoAddressBelongsToCurrentAccount")
+	}
+	return mock.CheckIfCryptoAddressBelongsToCurrentAccountFunc(ctx, arguments...)
+}
+
+// TransferBetweenWalletAndExchange calls TransferBetweenWalletAndExchangeFunc
+func (mock *MockWalletManagement) TransferBetweenWalletAndExchange(ctx context.Context, arguments ...args.Argument) (string, error) {
+	if mock.TransferBetweenWalletAndExchangeFunc == nil {
+		var r0 string
+		return r0, errNotMocked("MockWalletManagement.TransferBetweenWalletAndExchange")
+	}
+	return mock.TransferBetweenWalletAndExchangeFunc(ctx, arguments...)
+}
+
+// TransferMoneyToAnotherUser calls TransferMoneyToAnotherUserFunc
+func (mock *MockWalletManagement) TransferMoneyToAnotherUser(ctx context.Context, arguments ...args.Argument) (string, error) {
+	if mock.TransferMoneyToAnotherUserFunc == nil {
+		var r0 string
+		return r0, errNotMocked("MockWalletManagement.TransferMoneyToAnotherUser")
+	}
+	return mock.TransferMoneyToAnotherUserFunc(ctx, arguments...)
+}
+
+// GetTransactionHistory calls GetTransactionHistoryFunc
+func (mock *MockWalletManagement) GetTransactionHistory(ctx context.Context, arguments ...args.Argument) ([]models.Transaction, error) {
+	if mock.GetTransactionHistoryFunc == nil {
+		var r0 []models.Transaction
+		return r0, errNotMocked("MockWalletManagement.GetTransactionHistory")
+	}
+	return mock.GetTransactionHistoryFunc(ctx, arguments...)
+}
+
+// IterateTransactionHistory calls IterateTransactionHistoryFunc
+func (mock *MockWalletManagement) IterateTransactionHistory(ctx context.Context, arguments ...args.Argument) *Iterator[models.Transaction] {
+	if mock.IterateTransactionHistoryFunc == nil {
+		var r0 *Iterator[models.Transaction]
+		return r0
+	}
+	return mock.IterateTransactionHistoryFunc(ctx, arguments...)
+}
+
+// GetTransaction calls GetTransactionFunc
+func (mock *MockWalletManagement) GetTransaction(ctx context.Context, arguments ...args.Argument) (*models.Transaction, error) {
+	if mock.GetTransactionFunc == nil {
+		var r0 *models.Transaction
+		return r0, errNotMocked("MockWalletManagement.GetTransaction")
+	}
+	return mock.GetTransactionFunc(ctx, arguments...)
+}
+
+// CheckIfOffchainIsAvailable calls CheckIfOffchainIsAvailableFunc
+func (mock *MockWalletManagement) CheckIfOffchainIsAvailable(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.CheckIfOffchainIsAvailableFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockWalletManagement.CheckIfOffchainIsAvailable")
+	}
+	return mock.CheckIfOffchainIsAvailableFunc(ctx, arguments...)
+}
+
+// GetAmountLocks calls GetAmountLocksFunc
+func (mock *MockWalletManagement) GetAmountLocks(ctx context.Context, arguments ...args.Argument) ([]models.AmountLock, error) {
+	if mock.GetAmountLocksFunc == nil {
+		var r0 []models.AmountLock
+		return r0, errNotMocked("MockWalletManagement.GetAmountLocks")
+	}
+	return mock.GetAmountLocksFunc(ctx, arguments...)
+}
+
+var _ WalletManagement = (*MockWalletManagement)(nil)
+
+// MockSubAccounts is a mock of SubAccounts. Each method calls the field of its name with the Func suffix,
+// and fails as not mocked if the field is nil
+type MockSubAccounts struct {
+	GetSubAccountsFunc              func(ctx context.Context) ([]models.SubAccount, error)
+	FreezeSubAccountsFunc           func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	ActivateSubAccountsFunc         func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	TransferFundsFunc               func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	TransferToSuperAccountFunc      func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	TransferToAnotherSubAccountFunc func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	GetACLSettingsFunc              func(ctx context.Context, arguments ...args.Argument) ([]models.ACLSettings, error)
+	ChangeACLSettingsFunc           func(ctx context.Context, arguments ...args.Argument) (bool, error)
+	GetSubAccountBalancesFunc       func(ctx context.Context, arguments ...args.Argument) (models.SubAccountBalances, error)
+	GetSubAccountCryptoAddressFunc  func(ctx context.Context, arguments ...args.Argument) (string, error)
+}
+
+// GetSubAccounts calls GetSubAccountsFunc
+func (mock *MockSubAccounts) GetSubAccounts(ctx context.Context) ([]models.SubAccount, error) {
+	if mock.GetSubAccountsFunc == nil {
+		var r0 []models.SubAccount
+		return r0, errNotMocked("MockSubAccounts.GetSubAccounts")
+	}
+	return mock.GetSubAccountsFunc(ctx)
+}
+
+// FreezeSubAccounts calls FreezeSubAccountsFunc
+func (mock *MockSubAccounts) FreezeSubAccounts(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.FreezeSubAccountsFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockSubAccounts.FreezeSubAccounts")
+	}
+	return mock.FreezeSubAccountsFunc(ctx, arguments...)
+}
+
+// ActivateSubAccounts calls ActivateSubAccountsFunc
+func (mock *MockSubAccounts) ActivateSubAccounts(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.ActivateSubAccountsFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockSubAccounts.ActivateSubAccounts")
+	}
+	return mock.ActivateSubAccountsFunc(ctx, arguments...)
+}
+
+// TransferFunds calls TransferFundsFunc
+func (mock *MockSubAccounts) TransferFunds(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.TransferFundsFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockSubAccounts.TransferFunds")
+	}
+	return mock.TransferFundsFunc(ctx, arguments...)
+}
+
+// TransferToSuperAccount calls TransferToSuperAccountFunc
+func (mock *MockSubAccounts) TransferToSuperAccount(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.TransferToSuperAccountFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockSubAccounts.TransferToSuperAccount")
+	}
+	return mock.TransferToSuperAccountFunc(ctx, arguments...)
+}
+
+// TransferToAnotherSubAccount calls TransferToAnotherSubAccountFunc
+func (mock *MockSubAccounts) TransferToAnotherSubAccount(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.TransferToAnotherSubAccountFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockSubAccounts.TransferToAnotherSubAccount")
+	}
+	return mock.TransferToAnotherSubAccountFunc(ctx, arguments...)
+}
+
+// GetACLSettings calls GetACLSettingsFunc
+func (mock *MockSubAccounts) GetACLSettings(ctx context.Context, arguments ...args.Argument) ([]models.ACLSettings, error) {
+	if mock.GetACLSettingsFunc == nil {
+		var r0 []models.ACLSettings
+		return r0, errNotMocked("MockSubAccounts.GetACLSettings")
+	}
+	return mock.GetACLSettingsFunc(ctx, arguments...)
+}
+
+// ChangeACLSettings calls ChangeACLSettingsFunc
+func (mock *MockSubAccounts) ChangeACLSettings(ctx context.Context, arguments ...args.Argument) (bool, error) {
+	if mock.ChangeACLSettingsFunc == nil {
+		var r0 bool
+		return r0, errNotMocked("MockSubAccounts.ChangeACLSettings")
+	}
+	return mock.ChangeACLSettingsFunc(ctx, arguments...)
+}
+
+// GetSubAccountBalances calls GetSubAccountBalancesFunc
+func (mock *MockSubAccounts) GetSubAccountBalances(ctx context.Context, arguments ...args.Argument) (models.SubAccountBalances, error) {
+	if mock.GetSubAccountBalancesFunc == nil {
+		var r0 models.SubAccountBalances
+		return r0, errNotMocked("MockSubAccounts.GetSubAccountBalances")
+	}
+	return mock.GetSubAccountBalancesFunc(ctx, arguments...)
+}
+
+// GetSubAccountCryptoAddress calls GetSubAccountCryptoAddressFunc
+func (mock *MockSubAccounts) GetSubAccountCryptoAddress(ctx context.Context, arguments ...args.Argument) (string, error) {
+	if mock.GetSubAccountCryptoAddressFunc == nil {
+		var r0 string
+		return r0, errNotMocked("MockSubAccounts.GetSubAccountCryptoAddress")
+	}
+	return mock.GetSubAccountCryptoAddressFunc(ctx, arguments...)
+}
+
+var _ SubAccounts = (*MockSubAccounts)(nil)
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+func TestMockSpotTrading(t *testing.T) {
+	var symbol string
+	mock := &MockSpotTrading{
+		CreateSpotOrderFunc: func(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+			params, _ := args.BuildParams(arguments)
+			symbol = params["symbol"].(string)
+			return &models.Order{ClientOrderID: "mocked"}, nil
+		},
+	}
+	var trading SpotTrading = mock
+	order, err := trading.CreateSpotOrder(context.Background(), args.Symbol("EOSETH"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ClientOrderID != "mocked" || symbol != "EOSETH" {
+		t.Fatalf("unexpected order %+v for %s", order, symbol)
+	}
+	_, err = trading.CancelSpotOrder(context.Background(), args.ClientOrderID("mocked"))
+	var sdkErr *models.SDKError
+	if !errors.As(err, &sdkErr) {
+		t.Fatalf("expected a not mocked error, got %v", err)
+	}
+}
+
+func TestMockIterators(t *testing.T) {
+	failure := errors.New("failure")
+	var history SpotTradingHistory = &MockSpotTradingHistory{
+		IterateSpotTradesHistoryFunc: func(ctx context.Context, arguments ...args.Argument) *Iterator[models.Trade] {
+			return IteratorOf([]models.Trade{{ID: 1}, {ID: 2}}, failure)
+		},
+	}
+	iterator := history.IterateSpotTradesHistory(context.Background())
+	var ids []int64
+	for iterator.Next() {
+		ids = append(ids, iterator.Value().ID)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 || !errors.Is(iterator.Err(), failure) {
+		t.Fatalf("unexpected trades %v and error %v", ids, iterator.Err())
+	}
+	orders := history.IterateSpotOrdersHistory(context.Background())
+	var sdkErr *models.SDKError
+	if orders.Next() || !errors.As(orders.Err(), &sdkErr) {
+		t.Fatalf("expected a not mocked error, got %v", orders.Err())
+	}
+}
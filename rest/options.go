@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
+	"github.com/cryptomkt/cryptomkt-go/v3/ratelimit"
+)
+
+// ClientOption configures a Client built with NewClientWithOptions.
+type ClientOption func(*clientConfig)
+
+type clientConfig struct {
+	baseURL             string
+	httpClient          *http.Client
+	transport           http.RoundTripper
+	timeout             time.Duration
+	proxy               func(*http.Request) (*url.URL, error)
+	userAgentSuffix     string
+	credentialsProvider CredentialsProvider
+	window              int
+	retryPolicy         RetryPolicy
+	rateLimiter         *ratelimit.Limiter
+	interceptors        []Interceptor
+	clock               *auth.Clock
+	orderValidator      OrderValidator
+}
+
+// WithBaseURL sets the base url of the exchange, without the api version path.
+// Useful to target a sandbox, a recording proxy or an httptest.Server.
+// Default is https://api.exchange.cryptomkt.com
+func WithBaseURL(baseURL string) ClientOption {
+	return func(config *clientConfig) {
+		config.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the http.Client used for the requests.
+// The client is copied, so later changes to it do not affect the rest Client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(config *clientConfig) {
+		config.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for the requests.
+// Takes precedence over the transport of a client given with WithHTTPClient.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(config *clientConfig) {
+		config.transport = transport
+	}
+}
+
+// WithTimeout sets the time limit of each request, including connection time, redirects and reading the response body.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.timeout = timeout
+	}
+}
+
+// WithProxy routes all requests through the given proxy url.
+// Only applies to *http.Transport transports, other round trippers are used as given.
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(config *clientConfig) {
+		config.proxy = http.ProxyURL(proxyURL)
+	}
+}
+
+// WithUserAgentSuffix appends a suffix to the User-Agent header of every request.
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(config *clientConfig) {
+		config.userAgentSuffix = suffix
+	}
+}
+
+// WithCredentials sets the api key and api secret used to authenticate private calls.
+func WithCredentials(apiKey, apiSecret string) ClientOption {
+	return func(config *clientConfig) {
+		config.credentialsProvider = NewStaticCredentials(apiKey, apiSecret)
+	}
+}
+
+// WithWindow sets the window of execution for requests to the server in milliseconds.
+// Max is 60_000. Use 0 for the default window (10 seconds)
+func WithWindow(window int) ClientOption {
+	return func(config *clientConfig) {
+		config.window = window
+	}
+}
+
+// WithRateLimiter throttles the requests of the client with the given limiter.
+// The limiter can be shared with other clients trading on the same account.
+func WithRateLimiter(limiter *ratelimit.Limiter) ClientOption {
+	return func(config *clientConfig) {
+		config.rateLimiter = limiter
+	}
+}
+
+// WithClock calibrates the timestamps of signed requests with the given clock, measuring
+// the skew of the local clock from the Date header and the error responses of the exchange.
+// The clock can be shared with the websocket clients.
+func WithClock(clock *auth.Clock) ClientOption {
+	return func(config *clientConfig) {
+		config.clock = clock
+	}
+}
+
+func newClientConfig(options []ClientOption) *clientConfig {
+	config := &clientConfig{baseURL: defaultBaseURL}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+func (config *clientConfig) buildHTTPClient() *http.Client {
+	httpClient := &http.Client{}
+	if config.httpClient != nil {
+		copied := *config.httpClient
+		httpClient = &copied
+	}
+	if config.transport != nil {
+		httpClient.Transport = config.transport
+	}
+	if config.timeout != 0 {
+		httpClient.Timeout = config.timeout
+	}
+	if config.proxy != nil {
+		httpClient.Transport = withProxy(httpClient.Transport, config.proxy)
+	}
+	return httpClient
+}
+
+func withProxy(
+	transport http.RoundTripper,
+	proxy func(*http.Request) (*url.URL, error),
+) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	httpTransport, ok := transport.(*http.Transport)
+	if !ok {
+		return transport
+	}
+	httpTransport = httpTransport.Clone()
+	httpTransport.Proxy = proxy
+	return httpTransport
+}
+
+func (config *clientConfig) buildUserAgent() string {
+	if config.userAgentSuffix == "" {
+		return userAgentCryptomarketGo
+	}
+	return userAgentCryptomarketGo + " " + config.userAgentSuffix
+}
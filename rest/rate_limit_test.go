@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/ratelimit"
+)
+
+func TestRateLimitGroup(t *testing.T) {
+	cases := map[string]ratelimit.Group{
+		endpointCurrency:              ratelimit.GroupMarketData,
+		endpointOrderbook + "/EOSETH": ratelimit.GroupMarketData,
+		endpointOrder:                 ratelimit.GroupTrading,
+		endpointOrder + "/abc":        ratelimit.GroupTrading,
+		endpointOrderList:             ratelimit.GroupTrading,
+		endpointOrderHistory:          ratelimit.GroupOther,
+		endpointWalletBalance:         ratelimit.GroupOther,
+		endpointSubAccountList:        ratelimit.GroupOther,
+	}
+	for endpoint, expected := range cases {
+		if group := rateLimitGroup(endpoint); group != expected {
+			t.Errorf("%s: expected %s, got %s", endpoint, expected, group)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/internal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// RetryPolicy defines how requests failed by transport errors,
+// server errors (5xx) or rate limiting (429) are retried.
+//
+// Only idempotent requests are retried: GET requests, the cancellation of orders, and
+// requests carrying a ClientOrderID or a NewClientOrderID, as the exchange
+// rejects a duplicated client order id instead of executing it twice.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one. 1 or less disables retries
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled on every following retry
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts. A Retry-After header of the server takes precedence
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns a policy of 3 attempts with delays starting at 200 milliseconds
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the client. By default requests are not retried.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(config *clientConfig) {
+		config.retryPolicy = policy
+	}
+}
+
+type noRetriesKey struct{}
+
+// WithoutRetries returns a copy of the context that disables retries for the requests made with it
+func WithoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetriesKey{}, true)
+}
+
+func retriesDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noRetriesKey{}).(bool)
+	return disabled
+}
+
+func isIdempotent(method string, endpoint string, params map[string]interface{}) bool {
+	if method == methodGet {
+		return true
+	}
+	if method == methodDelete {
+		return isOrderCancel(endpoint)
+	}
+	if _, ok := params[internal.ArgNameClientOrderID]; ok {
+		return true
+	}
+	_, ok := params[internal.ArgNameNewClientOrderID]
+	return ok
+}
+
+// isOrderCancel tells if the endpoint cancels spot orders, the only DELETE requests that
+// are safe to repeat. Others, like the rollback of a withdrawal, are not retried
+func isOrderCancel(endpoint string) bool {
+	return endpoint == endpointOrder || strings.HasPrefix(endpoint, endpointOrder+"/")
+}
+
+func (policy RetryPolicy) attemptsFor(requestData *RequestData) int {
+	if policy.MaxAttempts < 1 || !requestData.idempotent || retriesDisabled(requestData.cxt) {
+		return 1
+	}
+	return policy.MaxAttempts
+}
+
+// delay returns the time to wait before the given retry, starting at 1,
+// with jitter in the upper half of the exponential backoff.
+func (policy RetryPolicy) delay(retry int, response *Response) time.Duration {
+	if retryAfter, ok := parseRetryAfter(response); ok {
+		return retryAfter
+	}
+	backoff := policy.BaseDelay << (retry - 1)
+	if backoff <= 0 || (policy.MaxDelay > 0 && backoff > policy.MaxDelay) {
+		backoff = policy.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+func shouldRetry(response *Response, err error) bool {
+	if err != nil {
+		var transportError *models.TransportError
+		return errors.As(err, &transportError)
+	}
+	if response == nil {
+		return false
+	}
+	return response.Status == http.StatusTooManyRequests || response.Status >= 500
+}
+
+func parseRetryAfter(response *Response) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
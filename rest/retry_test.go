@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+)
+
+func newFailingServer(failures int32, status int) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error":{"code":500,"message":"Internal Server Error"}}`))
+			return
+		}
+		w.Write([]byte(`{"id":1,"client_order_id":"abc"}`))
+	}))
+	return server, &calls
+}
+
+func newRetryingClient(baseURL string) *Client {
+	return NewClientWithOptions(
+		WithBaseURL(baseURL),
+		WithCredentials("key", "secret"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+	)
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	server, calls := newFailingServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+	client := newRetryingClient(server.URL)
+	if _, err := client.GetActiveSpotOrder(context.Background(), args.ClientOrderID("abc")); err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", *calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	server, calls := newFailingServer(5, http.StatusTooManyRequests)
+	defer server.Close()
+	client := newRetryingClient(server.URL)
+	if _, err := client.GetActiveSpotOrder(context.Background(), args.ClientOrderID("abc")); err == nil {
+		t.Fatal("should fail")
+	}
+	if *calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", *calls)
+	}
+}
+
+func TestNoRetryOfOrderWithoutClientOrderID(t *testing.T) {
+	server, calls := newFailingServer(1, http.StatusBadGateway)
+	defer server.Close()
+	client := newRetryingClient(server.URL)
+	_, err := client.CreateSpotOrder(
+		context.Background(),
+		args.Symbol("EOSETH"),
+		args.Side(args.SideBuy),
+		args.Quantity("1"),
+	)
+	if err == nil {
+		t.Fatal("should fail")
+	}
+	if *calls != 1 {
+		t.Fatalf("expected 1 call, got %d", *calls)
+	}
+}
+
+func TestRetryOfOrderWithClientOrderID(t *testing.T) {
+	server, calls := newFailingServer(1, http.StatusBadGateway)
+	defer server.Close()
+	client := newRetryingClient(server.URL)
+	_, err := client.CreateSpotOrder(
+		context.Background(),
+		args.Symbol("EOSETH"),
+		args.Side(args.SideBuy),
+		args.Quantity("1"),
+		args.ClientOrderID("abc"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", *calls)
+	}
+}
+
+func TestWithoutRetries(t *testing.T) {
+	server, calls := newFailingServer(1, http.StatusServiceUnavailable)
+	defer server.Close()
+	client := newRetryingClient(server.URL)
+	ctx := WithoutRetries(context.Background())
+	if _, err := client.GetActiveSpotOrder(ctx, args.ClientOrderID("abc")); err == nil {
+		t.Fatal("should fail")
+	}
+	if *calls != 1 {
+		t.Fatalf("expected 1 call, got %d", *calls)
+	}
+}
+
+func TestRetryOfOrderCancel(t *testing.T) {
+	server, calls := newFailingServer(1, http.StatusBadGateway)
+	defer server.Close()
+	client := newRetryingClient(server.URL)
+	if _, err := client.CancelSpotOrder(context.Background(), args.ClientOrderID("abc")); err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", *calls)
+	}
+}
+
+func TestNoRetryOfWithdrawRollback(t *testing.T) {
+	server, calls := newFailingServer(1, http.StatusBadGateway)
+	defer server.Close()
+	client := newRetryingClient(server.URL)
+	if _, err := client.WithdrawCryptoRollback(context.Background(), args.ID("abc")); err == nil {
+		t.Fatal("should fail")
+	}
+	if *calls != 1 {
+		t.Fatalf("expected 1 call, got %d", *calls)
+	}
+}
@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/internal"
+)
+
+// OrderValidator checks a new order before it is sent to the exchange, as a market.Validator
+type OrderValidator interface {
+	ValidateOrder(order args.OrderRequest) error
+}
+
+// WithOrderValidator checks the orders of CreateSpotOrder and CreateSpotOrderList with the validator,
+// failing with its error before any request to the exchange. Default is no validation
+func WithOrderValidator(validator OrderValidator) ClientOption {
+	return func(config *clientConfig) {
+		config.orderValidator = validator
+	}
+}
+
+// validateOrders validates the order of the params, or each order of an order list
+func validateOrders(validator OrderValidator, params map[string]interface{}) error {
+	if validator == nil {
+		return nil
+	}
+	orders, isList := params[internal.ArgNameOrders].([]args.OrderRequest)
+	if !isList {
+		orders = []args.OrderRequest{args.OrderRequestOf(params)}
+	}
+	for _, order := range orders {
+		if err := validator.ValidateOrder(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
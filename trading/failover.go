@@ -0,0 +1,171 @@
+package trading
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/internal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+type failover struct {
+	clients []SpotTrading
+}
+
+// NewFailover returns a SpotTrading that calls the primary, and the fallbacks in order
+// when a call fails in the transport, as with a closed websocket connection.
+// Errors of the exchange are returned without failing over.
+//
+// Every call starts from the primary. To make a failover safe when the request reached the
+// exchange but the response was lost, orders without a ClientOrderID (or NewClientOrderID in replaces)
+// are given one, so the exchange rejects a second placement as a duplicate. A duplicate of a generated id
+// after a failover is then fetched and returned as the result, while a duplicate of a given id is returned as an error
+func NewFailover(primary SpotTrading, fallbacks ...SpotTrading) SpotTrading {
+	return &failover{clients: append([]SpotTrading{primary}, fallbacks...)}
+}
+
+// call calls fn with each client until one does not fail in the transport.
+// Returns the result of the last call, whether it was a fallback and its error
+func call[T any](ctx context.Context, clients []SpotTrading, fn func(SpotTrading) (T, error)) (result T, fallback bool, err error) {
+	for i, client := range clients {
+		result, err = fn(client)
+		if err == nil || !isTransportError(err) || ctx.Err() != nil {
+			return result, i > 0, err
+		}
+	}
+	return result, len(clients) > 1, err
+}
+
+func isTransportError(err error) bool {
+	var transportError *models.TransportError
+	return errors.As(err, &transportError)
+}
+
+func isDuplicate(err error) bool {
+	var apiError *models.APIError
+	return errors.As(err, &apiError) && apiError.Code == models.ErrorCodeDuplicateClientOrderID
+}
+
+func (trading *failover) GetSpotTradingBalances(ctx context.Context) ([]models.Balance, error) {
+	balances, _, err := call(ctx, trading.clients, func(client SpotTrading) ([]models.Balance, error) {
+		return client.GetSpotTradingBalances(ctx)
+	})
+	return balances, err
+}
+
+func (trading *failover) GetSpotTradingBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error) {
+	balance, _, err := call(ctx, trading.clients, func(client SpotTrading) (*models.Balance, error) {
+		return client.GetSpotTradingBalanceOfCurrency(ctx, arguments...)
+	})
+	return balance, err
+}
+
+func (trading *failover) GetActiveSpotOrders(ctx context.Context, arguments ...args.Argument) ([]models.Order, error) {
+	orders, _, err := call(ctx, trading.clients, func(client SpotTrading) ([]models.Order, error) {
+		return client.GetActiveSpotOrders(ctx, arguments...)
+	})
+	return orders, err
+}
+
+func (trading *failover) GetActiveSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	order, _, err := call(ctx, trading.clients, func(client SpotTrading) (*models.Order, error) {
+		return client.GetActiveSpotOrder(ctx, arguments...)
+	})
+	return order, err
+}
+
+func (trading *failover) CreateSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	arguments, generatedID, err := withOrderID(arguments, internal.ArgNameClientOrderID, args.ClientOrderID)
+	if err != nil {
+		return nil, err
+	}
+	return trading.placeOrder(ctx, generatedID, func(client SpotTrading) (*models.Order, error) {
+		return client.CreateSpotOrder(ctx, arguments...)
+	})
+}
+
+func (trading *failover) ReplaceSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	arguments, generatedID, err := withOrderID(arguments, internal.ArgNameNewClientOrderID, args.NewClientOrderID)
+	if err != nil {
+		return nil, err
+	}
+	return trading.placeOrder(ctx, generatedID, func(client SpotTrading) (*models.Order, error) {
+		return client.ReplaceSpotOrder(ctx, arguments...)
+	})
+}
+
+// placeOrder calls fn, resolving a duplicate of the generated id after a failover with the active order of the id
+func (trading *failover) placeOrder(ctx context.Context, generatedID string, fn func(SpotTrading) (*models.Order, error)) (*models.Order, error) {
+	var placedBy SpotTrading
+	order, fallback, err := call(ctx, trading.clients, func(client SpotTrading) (*models.Order, error) {
+		placedBy = client
+		return fn(client)
+	})
+	if generatedID != "" && fallback && isDuplicate(err) {
+		if active, activeErr := placedBy.GetActiveSpotOrder(ctx, args.ClientOrderID(generatedID)); activeErr == nil {
+			return active, nil
+		}
+	}
+	return order, err
+}
+
+// CreateSpotOrderList gives a client order id to the orders without one. A duplicate after a failover
+// is returned as an error, as the list placed by the previous client can't be fetched as a whole
+func (trading *failover) CreateSpotOrderList(ctx context.Context, arguments ...args.Argument) ([]models.Order, error) {
+	params, err := args.BuildParams(arguments)
+	if err != nil {
+		return nil, err
+	}
+	if requests, ok := params[internal.ArgNameOrders].([]args.OrderRequest); ok {
+		withIDs := make([]args.OrderRequest, len(requests))
+		for i, request := range requests {
+			if request.ClientOrderID == "" {
+				request.ClientOrderID = newClientOrderID()
+			}
+			withIDs[i] = request
+		}
+		arguments = append(arguments[:len(arguments):len(arguments)], args.Orders(withIDs))
+	}
+	orders, _, err := call(ctx, trading.clients, func(client SpotTrading) ([]models.Order, error) {
+		return client.CreateSpotOrderList(ctx, arguments...)
+	})
+	return orders, err
+}
+
+func (trading *failover) CancelSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	order, _, err := call(ctx, trading.clients, func(client SpotTrading) (*models.Order, error) {
+		return client.CancelSpotOrder(ctx, arguments...)
+	})
+	return order, err
+}
+
+func (trading *failover) CancelAllSpotOrders(ctx context.Context) ([]models.Order, error) {
+	orders, _, err := call(ctx, trading.clients, func(client SpotTrading) ([]models.Order, error) {
+		return client.CancelAllSpotOrders(ctx)
+	})
+	return orders, err
+}
+
+// withOrderID returns the arguments with a generated order id if the argument of the key is missing,
+// and the generated id. Empty if the id is given
+func withOrderID(arguments []args.Argument, key string, argument func(string) args.Argument) ([]args.Argument, string, error) {
+	params, err := args.BuildParams(arguments)
+	if err != nil {
+		return nil, "", err
+	}
+	if id, ok := params[key].(string); ok && id != "" {
+		return arguments, "", nil
+	}
+	id := newClientOrderID()
+	return append(arguments[:len(arguments):len(arguments)], argument(id)), id, nil
+}
+
+// newClientOrderID returns a random id of 32 hex characters, the max length of the exchange
+func newClientOrderID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
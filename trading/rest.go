@@ -0,0 +1,22 @@
+package trading
+
+import (
+	"context"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/cryptomkt/cryptomkt-go/v3/rest"
+)
+
+type restTrading struct {
+	rest.SpotTrading
+}
+
+// NewREST returns the SpotTrading of a rest client
+func NewREST(client rest.SpotTrading) SpotTrading {
+	return restTrading{client}
+}
+
+func (trading restTrading) GetActiveSpotOrders(ctx context.Context, arguments ...args.Argument) ([]models.Order, error) {
+	return trading.GetAllActiveSpotOrders(ctx, arguments...)
+}
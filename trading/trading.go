@@ -0,0 +1,32 @@
+// Package trading puts the rest and websocket trading clients behind one interface.
+// Both answer with orders of the rest api, so strategy code can send its orders
+// over either transport, or fail over from one to the other:
+//
+//	restClient := rest.NewClient(apiKey, apiSecret, 0)
+//	wsClient, err := websocket.NewSpotTradingClient(apiKey, apiSecret, 0)
+//	trader := trading.NewFailover(trading.NewWebsocket(wsClient), trading.NewREST(restClient))
+//	order, err := trader.CreateSpotOrder(ctx, args.Symbol("EOSETH"), args.Side(args.SideBuy), args.Quantity("1"), args.Price("0.01"))
+package trading
+
+import (
+	"context"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// SpotTrading manages the spot orders and balances over any transport.
+// Arguments are the ones of the spot trading methods of the rest client
+type SpotTrading interface {
+	GetSpotTradingBalances(ctx context.Context) ([]models.Balance, error)
+	GetSpotTradingBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error)
+	// GetActiveSpotOrders gets the active orders, of a symbol if given the Symbol argument
+	GetActiveSpotOrders(ctx context.Context, arguments ...args.Argument) ([]models.Order, error)
+	// GetActiveSpotOrder gets an active order by its ClientOrderID
+	GetActiveSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	CreateSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	CreateSpotOrderList(ctx context.Context, arguments ...args.Argument) ([]models.Order, error)
+	ReplaceSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	CancelSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error)
+	CancelAllSpotOrders(ctx context.Context) ([]models.Order, error)
+}
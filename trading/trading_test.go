@@ -0,0 +1,123 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/cryptomkttest"
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+func TestTransportsReturnOrders(t *testing.T) {
+	server := cryptomkttest.NewServer()
+	defer server.Close()
+	server.SetSpotBalance("USDT", "1000")
+	wsClient, err := server.SpotTradingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wsClient.Close()
+	ctx := context.Background()
+	for name, trader := range map[string]SpotTrading{"rest": NewREST(server.Client()), "websocket": NewWebsocket(wsClient)} {
+		order, err := trader.CreateSpotOrder(ctx,
+			args.ClientOrderID(name),
+			args.Symbol("BTCUSDT"),
+			args.Side(args.SideBuy),
+			args.Quantity("0.01"),
+			args.Price("19000"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.ClientOrderID != name || order.Status != args.OrderStatusNew || order.Side != "buy" {
+			t.Fatalf("unexpected order of %s %+v", name, order)
+		}
+		active, err := trader.GetActiveSpotOrder(ctx, args.ClientOrderID(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !active.Price.Equal(decimal.MustParse("19000")) {
+			t.Fatalf("unexpected active order of %s %+v", name, active)
+		}
+		if _, err := trader.CancelSpotOrder(ctx, args.ClientOrderID(name)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := trader.GetActiveSpotOrder(ctx, args.ClientOrderID(name)); !errors.Is(err, models.ErrOrderNotFound) {
+			t.Fatalf("expected order not found of %s, got %v", name, err)
+		}
+	}
+}
+
+func TestFailoverOnClosedConnection(t *testing.T) {
+	server := cryptomkttest.NewServer()
+	defer server.Close()
+	server.SetSpotBalance("USDT", "1000")
+	wsClient, err := server.SpotTradingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wsClient.Close()
+	trader := NewFailover(NewWebsocket(wsClient), NewREST(server.Client()))
+	order, err := trader.CreateSpotOrder(context.Background(),
+		args.Symbol("BTCUSDT"),
+		args.Side(args.SideBuy),
+		args.Quantity("0.01"),
+		args.Price("19000"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order.ClientOrderID) != 32 {
+		t.Fatalf("expected a generated client order id, got %q", order.ClientOrderID)
+	}
+	if orders := server.ActiveOrders(); len(orders) != 1 {
+		t.Fatalf("unexpected active orders %+v", orders)
+	}
+	// a duplicate of a given id is an error
+	_, err = trader.CreateSpotOrder(context.Background(), args.ClientOrderID(order.ClientOrderID),
+		args.Symbol("BTCUSDT"),
+		args.Side(args.SideBuy),
+		args.Quantity("0.01"),
+		args.Price("19000"),
+	)
+	var apiError *models.APIError
+	if !errors.As(err, &apiError) || apiError.Code != models.ErrorCodeDuplicateClientOrderID {
+		t.Fatalf("expected a duplicate, got %v", err)
+	}
+}
+
+// lostResponse places the orders and fails as if the response was lost
+type lostResponse struct {
+	SpotTrading
+}
+
+func (trading lostResponse) CreateSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	if _, err := trading.SpotTrading.CreateSpotOrder(ctx, arguments...); err != nil {
+		return nil, err
+	}
+	return nil, &models.TransportError{Err: errors.New("connection reset")}
+}
+
+func TestFailoverResolvesDuplicates(t *testing.T) {
+	server := cryptomkttest.NewServer()
+	defer server.Close()
+	server.SetSpotBalance("USDT", "1000")
+	restTrading := NewREST(server.Client())
+	trader := NewFailover(lostResponse{restTrading}, restTrading)
+	order, err := trader.CreateSpotOrder(context.Background(),
+		args.Symbol("BTCUSDT"),
+		args.Side(args.SideBuy),
+		args.Quantity("0.01"),
+		args.Price("19000"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orders := server.ActiveOrders()
+	if len(orders) != 1 || orders[0].ClientOrderID != order.ClientOrderID {
+		t.Fatalf("expected the order placed once, got %+v", orders)
+	}
+}
@@ -0,0 +1,102 @@
+package trading
+
+import (
+	"context"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/internal"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/cryptomkt/cryptomkt-go/v3/websocket"
+)
+
+type websocketTrading struct {
+	client websocket.SpotTrading
+}
+
+// NewWebsocket returns the SpotTrading of a websocket trading client.
+// The reports of the client are converted to orders with models.FromReportToOrder
+func NewWebsocket(client websocket.SpotTrading) SpotTrading {
+	return websocketTrading{client}
+}
+
+func (trading websocketTrading) GetSpotTradingBalances(ctx context.Context) ([]models.Balance, error) {
+	return trading.client.GetSpotTradingBalances(ctx)
+}
+
+func (trading websocketTrading) GetSpotTradingBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error) {
+	return trading.client.GetSpotTradingBalanceOfCurrency(ctx, arguments...)
+}
+
+// GetActiveSpotOrders filters the active orders by symbol in the client, as the websocket api has no filter
+func (trading websocketTrading) GetActiveSpotOrders(ctx context.Context, arguments ...args.Argument) ([]models.Order, error) {
+	params, err := args.BuildParams(arguments)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := trading.client.GetActiveSpotOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	symbol, _ := params[internal.ArgNameSymbol].(string)
+	orders := make([]models.Order, 0, len(reports))
+	for _, report := range reports {
+		if symbol == "" || report.Symbol == symbol {
+			orders = append(orders, models.FromReportToOrder(report))
+		}
+	}
+	return orders, nil
+}
+
+func (trading websocketTrading) GetActiveSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	params, err := args.BuildParams(arguments, internal.ArgNameClientOrderID)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := trading.client.GetActiveSpotOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range reports {
+		if report.ClientOrderID == params[internal.ArgNameClientOrderID] {
+			order := models.FromReportToOrder(report)
+			return &order, nil
+		}
+	}
+	return nil, &models.APIError{Code: models.ErrorCodeOrderNotFound, Message: "Order not found"}
+}
+
+func (trading websocketTrading) CreateSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	return orderOf(trading.client.CreateSpotOrder(ctx, arguments...))
+}
+
+func (trading websocketTrading) CreateSpotOrderList(ctx context.Context, arguments ...args.Argument) ([]models.Order, error) {
+	reports, err := trading.client.CreateSpotOrderList(ctx, arguments...)
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]models.Order, 0, len(reports))
+	for _, report := range reports {
+		orders = append(orders, models.FromReportToOrder(report))
+	}
+	return orders, nil
+}
+
+func (trading websocketTrading) ReplaceSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	return orderOf(trading.client.ReplaceSpotOrder(ctx, arguments...))
+}
+
+func (trading websocketTrading) CancelSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Order, error) {
+	return orderOf(trading.client.CancelSpotOrder(ctx, arguments...))
+}
+
+func (trading websocketTrading) CancelAllSpotOrders(ctx context.Context) ([]models.Order, error) {
+	return trading.client.CancelAllSpotOrders(ctx)
+}
+
+func orderOf(report *models.Report, err error) (*models.Order, error) {
+	if err != nil {
+		return nil, err
+	}
+	order := models.FromReportToOrder(*report)
+	return &order, nil
+}
@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"sync"
+	"time"
 )
 
 type chanCache struct {
@@ -10,7 +11,10 @@ type chanCache struct {
 	notificationsChans    map[int64]*reusableChan
 	notificationsChanLock *sync.Mutex
 	subscriptionChans     map[string]chan []byte
+	activeSubscriptions   map[string][]subscriptionRequest
 	subscriptionChansLock *sync.RWMutex
+	lastData              map[string]time.Time
+	lastDataLock          *sync.Mutex
 }
 
 func newChanCache() *chanCache {
@@ -20,7 +24,10 @@ func newChanCache() *chanCache {
 		notificationsChans:    make(map[int64]*reusableChan),
 		notificationsChanLock: new(sync.Mutex),
 		subscriptionChans:     make(map[string]chan []byte),
+		activeSubscriptions:   make(map[string][]subscriptionRequest),
 		subscriptionChansLock: new(sync.RWMutex),
+		lastData:              make(map[string]time.Time),
+		lastDataLock:          new(sync.Mutex),
 	}
 }
 
@@ -35,6 +42,7 @@ func (cache *chanCache) closeSubscriptionChs() {
 	for key, ch := range cache.subscriptionChans {
 		close(ch)
 		delete(cache.subscriptionChans, key)
+		delete(cache.activeSubscriptions, key)
 	}
 }
 
@@ -42,7 +50,7 @@ func (cache *chanCache) closeNotificationChs() {
 	cache.notificationsChanLock.Lock()
 	defer cache.notificationsChanLock.Unlock()
 	for key, ch := range cache.notificationsChans {
-		close(ch.channel)
+		ch.close()
 		delete(cache.notificationsChans, key)
 	}
 }
@@ -79,6 +87,13 @@ func (cache *chanCache) getChan(id int64) (*reusableChan, bool) {
 	return reusableCh, true
 }
 
+// removeCh forgets the channel of the id without closing it. Later responses of the id are dropped
+func (cache *chanCache) removeCh(id int64) {
+	cache.notificationsChanLock.Lock()
+	defer cache.notificationsChanLock.Unlock()
+	delete(cache.notificationsChans, id)
+}
+
 func (cache *chanCache) closeAndRemoveCh(id int64) {
 	cache.notificationsChanLock.Lock()
 	defer cache.notificationsChanLock.Unlock()
@@ -94,10 +109,36 @@ func (cache *chanCache) sendViaSubscriptionCh(key string, data []byte) {
 	cache.subscriptionChansLock.RLock()
 	defer cache.subscriptionChansLock.RUnlock()
 	if ch, ok := cache.subscriptionChans[key]; ok {
+		cache.touch(key)
 		ch <- data
 	}
 }
 
+// touch records data of the subscription channel of the key now
+func (cache *chanCache) touch(key string) {
+	cache.lastDataLock.Lock()
+	defer cache.lastDataLock.Unlock()
+	cache.lastData[key] = time.Now()
+}
+
+// staleSubscription returns the key of an active subscription without data for the timeout, counting from since at most
+func (cache *chanCache) staleSubscription(timeout time.Duration, since time.Time) (string, bool) {
+	cache.subscriptionChansLock.RLock()
+	defer cache.subscriptionChansLock.RUnlock()
+	cache.lastDataLock.Lock()
+	defer cache.lastDataLock.Unlock()
+	for key := range cache.activeSubscriptions {
+		last := cache.lastData[key]
+		if last.Before(since) {
+			last = since
+		}
+		if time.Since(last) > timeout {
+			return key, true
+		}
+	}
+	return "", false
+}
+
 func (cache *chanCache) saveSubscriptionCh(key string, ch chan []byte) {
 	cache.subscriptionChansLock.Lock()
 	defer cache.subscriptionChansLock.Unlock()
@@ -111,6 +152,39 @@ func (cache *chanCache) deleteSubscriptionCh(key string) {
 		close(ch)
 		delete(cache.subscriptionChans, key)
 	}
+	delete(cache.activeSubscriptions, key)
+}
+
+// removeSubscriptionCh closes and deletes the subscription channel of the key if it is still ch,
+// undoing a failed subscription without closing a channel shared with previous subscriptions
+func (cache *chanCache) removeSubscriptionCh(key string, ch chan []byte) {
+	cache.subscriptionChansLock.Lock()
+	defer cache.subscriptionChansLock.Unlock()
+	if current, ok := cache.subscriptionChans[key]; ok && current == ch {
+		close(ch)
+		delete(cache.subscriptionChans, key)
+		delete(cache.activeSubscriptions, key)
+	}
+}
+
+// saveSubscriptionRequest keeps the request of a subscription of the channel of the key, to request it again on reconnection
+func (cache *chanCache) saveSubscriptionRequest(key string, request subscriptionRequest) {
+	cache.subscriptionChansLock.Lock()
+	defer cache.subscriptionChansLock.Unlock()
+	if _, ok := cache.subscriptionChans[key]; ok {
+		cache.activeSubscriptions[key] = append(cache.activeSubscriptions[key], request)
+	}
+}
+
+// subscriptionRequests returns the requests of the active subscriptions by the key of their channel
+func (cache *chanCache) subscriptionRequests() map[string][]subscriptionRequest {
+	cache.subscriptionChansLock.RLock()
+	defer cache.subscriptionChansLock.RUnlock()
+	requests := make(map[string][]subscriptionRequest, len(cache.activeSubscriptions))
+	for key, keyRequests := range cache.activeSubscriptions {
+		requests[key] = append([]subscriptionRequest(nil), keyRequests...)
+	}
+	return requests
 }
 
 func (cache *chanCache) getSubscriptionChan(key string) (chan []byte, bool) {
@@ -1,13 +1,33 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/cryptomkt/cryptomkt-go/v3/ratelimit"
 )
 
 type clientBase struct {
-	wsManager *wsManager
-	chanCache *chanCache
-	window    int
+	wsManager   *wsManager
+	chanCache   *chanCache
+	window      int
+	rateLimiter *ratelimit.Limiter
+	clock       *auth.Clock
+	// apiKey and signer log in authenticated clients, again on reconnection
+	apiKey string
+	signer auth.Signer
+	// subscriptionTimeout is the time without data before a subscription is stale, 0 never is
+	subscriptionTimeout time.Duration
+}
+
+// ClockSkew returns the measured offset of the exchange clock from the local clock.
+// Always 0 unless the client was created with the WithClock option
+func (client *clientBase) ClockSkew() time.Duration {
+	return client.clock.Skew()
 }
 
 // Close closes all the channels related to the client as well as the websocket connection.
@@ -17,7 +37,24 @@ func (client *clientBase) Close() {
 	client.wsManager.close()
 }
 
+// connect opens the connection and handles the incoming data, resyncing the client on reconnection
+func (client *clientBase) connect(ctx context.Context) error {
+	client.wsManager.onReconnect = client.resync
+	// the requests in flight on a lost connection will not be answered
+	client.wsManager.onDisconnect = client.chanCache.closeNotificationChs
+	if err := client.wsManager.connect(ctx); err != nil {
+		return err
+	}
+	go client.handle(client.wsManager.rcv)
+	if client.subscriptionTimeout > 0 {
+		go client.watchSubscriptions(client.subscriptionTimeout)
+	}
+	return nil
+}
+
 func (client *clientBase) handle(rcvCh chan []byte) {
+	// without connection, the channels of the client are closed
+	defer client.chanCache.close()
 	for data := range rcvCh {
 		resp := wsResponse{}
 		json.Unmarshal(data, &resp)
@@ -34,3 +71,8 @@ func (client *clientBase) handle(rcvCh chan []byte) {
 		}
 	}
 }
+
+// errConnectionClosed is a transport error, as the request never reaches the exchange
+func errConnectionClosed() error {
+	return &models.TransportError{Err: errors.New("websocket connection closed")}
+}
@@ -1,32 +1,39 @@
 package websocket
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
 	"encoding/json"
-	"fmt"
-	"strconv"
-	"time"
 
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
 	"github.com/cryptomkt/cryptomkt-go/v3/models"
 )
 
-func (client *clientBase) authenticate(apiKey, apiSecret string) (err error) {
-	if !client.wsManager.isOpen {
-		return fmt.Errorf("CryptomarketSDKError: websocket connection closed")
-	}
-	intTimestamp := time.Now().UnixMilli()
-	timestamp := strconv.FormatInt(intTimestamp, 10)
-	h := hmac.New(sha256.New, []byte(apiSecret))
-	h.Write([]byte(timestamp))
-	if client.window != 0 {
-		h.Write([]byte(fmt.Sprint(client.window)))
+// authenticate logs in the client, emitting EventLoggedIn or EventLoginFailed
+func (client *clientBase) authenticate(ctx context.Context) error {
+	err := client.login(ctx)
+	if err != nil {
+		client.wsManager.events.emit(Event{Type: EventLoginFailed, Err: err})
+		return err
+	}
+	client.wsManager.events.emit(Event{Type: EventLoggedIn})
+	return nil
+}
+
+func (client *clientBase) login(ctx context.Context) (err error) {
+	if !client.wsManager.connected() {
+		return errConnectionClosed()
+	}
+	intTimestamp := client.clock.UnixMilli()
+	signature, err := client.signer.Sign(ctx, auth.Payload{
+		Timestamp: intTimestamp,
+		Window:    client.window,
+	})
+	if err != nil {
+		return &models.SDKError{Message: "unable to sign the login", Err: err}
 	}
-	signature := hex.EncodeToString(h.Sum(nil))
 	params := map[string]interface{}{
 		"type":      "HS256",
-		"api_key":   apiKey,
+		"api_key":   client.apiKey,
 		"timestamp": intTimestamp,
 		"signature": signature,
 	}
@@ -43,16 +50,21 @@ func (client *clientBase) authenticate(apiKey, apiSecret string) (err error) {
 	data, err := json.Marshal(notification)
 	if err != nil {
 		client.chanCache.closeAndRemoveCh(id)
-		return fmt.Errorf("CryptomarketSDKError: invalid notification: %v", err)
+		return &models.SDKError{Message: "invalid notification", Err: err}
+	}
+	if err := client.sendRequest(ctx, id, data); err != nil {
+		return err
+	}
+	data, err = client.waitResponse(ctx, id, ch)
+	if err != nil {
+		return err
 	}
-	client.wsManager.snd <- data
-	data = <-ch
 	var resp struct {
 		Error *models.APIError
 	}
 	json.Unmarshal(data, &resp)
 	if resp.Error != nil {
-		return fmt.Errorf("CryptomarketAPIError: %v", resp.Error)
+		return resp.Error
 	}
 	return nil
 }
@@ -3,7 +3,6 @@ package websocket
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"reflect"
 
 	"github.com/cryptomkt/cryptomkt-go/v3/args"
@@ -39,10 +38,13 @@ func (client *clientBase) doRequestOfNNotifications(
 		return err
 	}
 	nNotifications := getNOfNotifications(params, nNotificationsParam)
-	if !client.wsManager.isOpen {
-		return fmt.Errorf("CryptomarketSDKError: websocket connection is closed")
+	if !client.wsManager.connected() {
+		return errConnectionClosed()
 	}
-	ch := make(chan []byte, 1)
+	if err := client.waitRateLimit(ctx, method); err != nil {
+		return err
+	}
+	ch := make(chan []byte, nNotifications)
 	id := client.chanCache.saveCh(ch, nNotifications)
 	notification := wsNotification{
 		ID:     id,
@@ -52,14 +54,42 @@ func (client *clientBase) doRequestOfNNotifications(
 	data, err := json.Marshal(notification)
 	if err != nil {
 		client.chanCache.closeAndRemoveCh(id)
-		return fmt.Errorf("CryptomarketSDKError: invalid notification: %v", err)
+		return &models.SDKError{Message: "invalid notification", Err: err}
+	}
+	if err := client.sendRequest(ctx, id, data); err != nil {
+		return err
 	}
-	client.sendRequest(data)
 	return client.handleResponse(ctx, id, ch, nNotifications, parseNotificationFn)
 }
 
-func (client *clientBase) sendRequest(data []byte) {
-	client.wsManager.snd <- data
+func (client *clientBase) waitRateLimit(ctx context.Context, method string) error {
+	if client.rateLimiter == nil {
+		return nil
+	}
+	return client.rateLimiter.Wait(ctx, rateLimitGroup(method))
+}
+
+// sendRequest sends the request of the id, forgetting it if the client is closed or the context is done
+func (client *clientBase) sendRequest(ctx context.Context, id int64, data []byte) error {
+	if err := client.wsManager.send(ctx, data); err != nil {
+		client.chanCache.removeCh(id)
+		return err
+	}
+	return nil
+}
+
+// waitResponse waits a response to the request of the id, forgetting the request if the context is done first
+func (client *clientBase) waitResponse(ctx context.Context, id int64, ch chan []byte) ([]byte, error) {
+	select {
+	case <-ctx.Done():
+		client.chanCache.removeCh(id)
+		return nil, ctx.Err()
+	case data, ok := <-ch:
+		if !ok {
+			return nil, errConnectionClosed()
+		}
+		return data, nil
+	}
 }
 
 func getNOfNotifications(params map[string]interface{}, nNotificationsParam string) int {
@@ -78,20 +108,18 @@ func getNOfNotifications(params map[string]interface{}, nNotificationsParam stri
 
 func (client *clientBase) handleResponse(ctx context.Context, id int64, ch chan []byte, nNotifications int, updateModelAggFn parseNotificationFnType) error {
 	for i := 0; i < nNotifications; i++ {
-		select {
-		case <-ctx.Done():
-			client.chanCache.closeAndRemoveCh(id)
-			return ctx.Err()
-		case data := <-ch:
-			var resp struct {
-				Error *models.APIError
-			}
-			json.Unmarshal(data, &resp)
-			if resp.Error != nil {
-				return fmt.Errorf("CryptomarketAPIError: %v", resp.Error)
-			}
-			updateModelAggFn(data)
+		data, err := client.waitResponse(ctx, id, ch)
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			Error *models.APIError
+		}
+		json.Unmarshal(data, &resp)
+		if resp.Error != nil {
+			return resp.Error
 		}
+		updateModelAggFn(data)
 	}
 	return nil
 }
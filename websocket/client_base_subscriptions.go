@@ -1,14 +1,15 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/cryptomkt/cryptomkt-go/v3/args"
 	"github.com/cryptomkt/cryptomkt-go/v3/models"
 )
 
 func (client *clientBase) doSubscription(
+	ctx context.Context,
 	method string,
 	arguments []args.Argument,
 	requiredArguments []string,
@@ -17,8 +18,8 @@ func (client *clientBase) doSubscription(
 	if err != nil {
 		return nil, err
 	}
-	if !client.wsManager.isOpen {
-		return nil, fmt.Errorf("CryptomarketSDKError: websocket connection closed")
+	if !client.wsManager.connected() {
+		return nil, errConnectionClosed()
 	}
 	ch := make(chan []byte, 1)
 	id := client.chanCache.saveCh(ch, 1)
@@ -31,25 +32,34 @@ func (client *clientBase) doSubscription(
 	data, err := json.Marshal(notification)
 	if err != nil {
 		client.chanCache.closeAndRemoveCh(id)
-		return nil, fmt.Errorf("CryptomarketSDKError: invalid notification: %v", err)
+		return nil, &models.SDKError{Message: "invalid notification", Err: err}
 	}
 	key := subscriptionMapping[method]
 	dataOut := make(chan []byte, 1)
 	client.chanCache.saveSubscriptionCh(key, dataOut)
-	client.wsManager.snd <- data
-	data = <-ch
+	if err := client.sendRequest(ctx, id, data); err != nil {
+		client.chanCache.removeSubscriptionCh(key, dataOut)
+		return nil, err
+	}
+	data, err = client.waitResponse(ctx, id, ch)
+	if err != nil {
+		client.chanCache.removeSubscriptionCh(key, dataOut)
+		return nil, err
+	}
 	var resp struct {
 		Error *models.APIError
 	}
 	json.Unmarshal(data, &resp)
 	if resp.Error != nil {
-		close(dataOut)
-		return nil, fmt.Errorf("CryptomarketAPIError: %v", resp.Error)
+		client.chanCache.removeSubscriptionCh(key, dataOut)
+		return nil, resp.Error
 	}
+	client.chanCache.saveSubscriptionRequest(key, subscriptionRequest{method: method, params: params})
 	return dataOut, nil
 }
 
 func (client *clientBase) doUnsubscription(
+	ctx context.Context,
 	method string,
 	arguments []args.Argument,
 	requiredArguments []string,
@@ -58,8 +68,8 @@ func (client *clientBase) doUnsubscription(
 	if err != nil {
 		return err
 	}
-	if !client.wsManager.isOpen {
-		return fmt.Errorf("CryptomarketSDKError: websocket connection closed")
+	if !client.wsManager.connected() {
+		return errConnectionClosed()
 	}
 	key := subscriptionMapping[method]
 	client.chanCache.deleteSubscriptionCh(key)
@@ -73,16 +83,21 @@ func (client *clientBase) doUnsubscription(
 	data, err := json.Marshal(notification)
 	if err != nil {
 		client.chanCache.closeAndRemoveCh(id)
-		return fmt.Errorf("CryptomarketSDKError: invalid notification: %v", err)
+		return &models.SDKError{Message: "invalid notification", Err: err}
+	}
+	if err := client.sendRequest(ctx, id, data); err != nil {
+		return err
+	}
+	data, err = client.waitResponse(ctx, id, ch)
+	if err != nil {
+		return err
 	}
-	client.wsManager.snd <- data
-	data = <-ch
 	var resp struct {
 		Error *models.APIError
 	}
 	json.Unmarshal(data, &resp)
 	if resp.Error != nil {
-		return fmt.Errorf("CryptomarketAPIError: %v", resp.Error)
+		return resp.Error
 	}
 	return nil
 }
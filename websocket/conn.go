@@ -0,0 +1,80 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Conn is a websocket connection used by the clients. Implemented by *websocket.Conn of gorilla/websocket
+type Conn interface {
+	ReadMessage() (messageType int, data []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+}
+
+// HeartbeatConn is a connection supporting pings and read deadlines. Implemented by *websocket.Conn of gorilla/websocket.
+// The heartbeat of the WithHeartbeat option only runs over connections implementing it
+type HeartbeatConn interface {
+	Conn
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	SetReadDeadline(t time.Time) error
+	SetPongHandler(handler func(appData string) error)
+}
+
+// Connector opens the connections of the clients, returning the handshake response if any.
+// Lets the clients run over recorded or fake connections.
+type Connector interface {
+	Connect(url string) (Conn, *http.Response, error)
+}
+
+// ConnectorFunc adapts a function to the Connector interface
+type ConnectorFunc func(url string) (Conn, *http.Response, error)
+
+// Connect calls the function
+func (fn ConnectorFunc) Connect(url string) (Conn, *http.Response, error) {
+	return fn(url)
+}
+
+// ContextConnector is a connector able to cancel the opening of a connection with a context.
+// Connectors not implementing it are not cancelled by the context of the client constructors
+type ContextConnector interface {
+	Connector
+	ConnectContext(ctx context.Context, url string) (Conn, *http.Response, error)
+}
+
+// dialerConnector dials with a gorilla/websocket dialer and the headers of the handshake
+type dialerConnector struct {
+	dialer *websocket.Dialer
+	header http.Header
+}
+
+// Connect dials the url
+func (connector dialerConnector) Connect(url string) (Conn, *http.Response, error) {
+	return connector.ConnectContext(context.Background(), url)
+}
+
+// ConnectContext dials the url, aborting the handshake when the context is done
+func (connector dialerConnector) ConnectContext(ctx context.Context, url string) (Conn, *http.Response, error) {
+	conn, response, err := connector.dialer.DialContext(ctx, url, connector.header)
+	if err != nil {
+		return nil, response, err
+	}
+	return conn, response, nil
+}
+
+// NewDialerConnector creates a connector dialing with the given gorilla/websocket dialer.
+// A nil dialer is the default dialer
+func NewDialerConnector(dialer *websocket.Dialer) Connector {
+	return newDialerConnector(dialer, nil)
+}
+
+// newDialerConnector creates a connector dialing with the dialer and the headers of the handshake
+func newDialerConnector(dialer *websocket.Dialer, header http.Header) Connector {
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	return dialerConnector{dialer: dialer, header: header}
+}
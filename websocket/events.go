@@ -0,0 +1,114 @@
+package websocket
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// EventType is a type of event of the lifecycle of the connection of a client
+type EventType string
+
+const (
+	// EventConnected is a new connection, on creation of the client and on reconnection
+	EventConnected EventType = "connected"
+	// EventLoggedIn is a successful login of an authenticated client
+	EventLoggedIn EventType = "logged_in"
+	// EventLoginFailed is a failed login of an authenticated client, with the error as cause
+	EventLoginFailed EventType = "login_failed"
+	// EventDisconnected is a lost connection, with the error as cause
+	EventDisconnected EventType = "disconnected"
+	// EventServerClosed is a connection closed by the exchange, with the close error as cause
+	EventServerClosed EventType = "server_closed"
+	// EventReconnecting is an attempt to reconnect, numbered by Attempt
+	EventReconnecting EventType = "reconnecting"
+	// EventReconnectFailed is a failed attempt to reconnect, numbered by Attempt, with the error as cause
+	EventReconnectFailed EventType = "reconnect_failed"
+	// EventReconnected is a reconnection with the client logged in and its subscriptions requested again
+	EventReconnected EventType = "reconnected"
+	// EventClosed is the last event of a client, closed by Close or by the loss of its connection, with the error as cause
+	EventClosed EventType = "closed"
+)
+
+// eventsBuffer is the number of events kept for a slow reader of Events before dropping new ones
+const eventsBuffer = 64
+
+// Event is an event of the lifecycle of the connection of a client
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Err is the cause of the event, if any
+	Err error
+	// Attempt is the number of the reconnection attempt of EventReconnecting events
+	Attempt int
+}
+
+// events is the stream of events of a client, closed after EventClosed
+type events struct {
+	ch     chan Event
+	lock   *sync.Mutex
+	closed bool
+}
+
+func newEvents() *events {
+	return &events{
+		ch:   make(chan Event, eventsBuffer),
+		lock: new(sync.Mutex),
+	}
+}
+
+// emit sends the event without blocking, dropping it if the buffer is full
+func (events *events) emit(event Event) {
+	event.Time = time.Now()
+	events.lock.Lock()
+	defer events.lock.Unlock()
+	if events.closed {
+		return
+	}
+	select {
+	case events.ch <- event:
+	default:
+	}
+}
+
+// close emits EventClosed with the cause and closes the stream. With the buffer full, the
+// oldest event is dropped to make room, so EventClosed is always the last event
+func (events *events) close(cause error) {
+	events.lock.Lock()
+	defer events.lock.Unlock()
+	if events.closed {
+		return
+	}
+	closed := Event{Type: EventClosed, Time: time.Now(), Err: cause}
+	select {
+	case events.ch <- closed:
+	default:
+		select {
+		case <-events.ch:
+		default:
+		}
+		events.ch <- closed
+	}
+	events.closed = true
+	close(events.ch)
+}
+
+// disconnectionOf returns the event of a lost connection by the cause
+func disconnectionOf(cause error) Event {
+	var closeErr *websocket.CloseError
+	// abnormal closures are not sent by the exchange, but reported for connections lost without a close frame
+	if errors.As(cause, &closeErr) && closeErr.Code != websocket.CloseAbnormalClosure {
+		return Event{Type: EventServerClosed, Err: cause}
+	}
+	return Event{Type: EventDisconnected, Err: cause}
+}
+
+// Events returns the events of the lifecycle of the connection of the client, as disconnections
+// and reconnections, to alert on them or measure the uptime of the session. Events are dropped
+// if the buffer of the channel is full, but EventClosed, which makes room for itself. The channel
+// is closed after the EventClosed event
+func (client *clientBase) Events() <-chan Event {
+	return client.wsManager.events.ch
+}
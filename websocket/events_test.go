@@ -0,0 +1,24 @@
+package websocket
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEventClosedWithFullBuffer(t *testing.T) {
+	events := newEvents()
+	for i := 0; i < eventsBuffer+10; i++ {
+		events.emit(Event{Type: EventReconnecting, Attempt: i + 1})
+	}
+	cause := errors.New("lost")
+	events.close(cause)
+	var last Event
+	count := 0
+	for event := range events.ch {
+		last = event
+		count++
+	}
+	if count != eventsBuffer || last.Type != EventClosed || last.Err != cause {
+		t.Fatalf("expected %d events ending with EventClosed, got %d ending with %+v", eventsBuffer, count, last)
+	}
+}
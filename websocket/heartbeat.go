@@ -0,0 +1,86 @@
+package websocket
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+	"github.com/gorilla/websocket"
+)
+
+// WithHeartbeat pings the exchange every ping interval. A connection without any data,
+// pongs included, for a ping interval and the pong timeout is stale, and is closed as lost.
+// A pong timeout of 0 is a ping interval. Only runs over connections implementing HeartbeatConn
+func WithHeartbeat(pingInterval, pongTimeout time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.pingInterval = pingInterval
+		config.pongTimeout = pongTimeout
+	}
+}
+
+// WithSubscriptionTimeout closes the connection as stale when a subscription receives no data
+// for the timeout, as a half-open connection would starve it. Each subscription is watched on
+// its own, so only use it with feeds sending data more often than the timeout, as tickers
+func WithSubscriptionTimeout(timeout time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.subscriptionTimeout = timeout
+	}
+}
+
+func (config *clientConfig) pongTimeoutOrDefault() time.Duration {
+	if config.pongTimeout > 0 {
+		return config.pongTimeout
+	}
+	return config.pingInterval
+}
+
+// keepAlive pings over the connection every ping interval until done, and sets a read
+// deadline extended by any data or pong
+func (ws *wsManager) keepAlive(conn Conn, done chan struct{}) {
+	heartbeat, ok := conn.(HeartbeatConn)
+	if !ok || ws.pingInterval <= 0 {
+		return
+	}
+	ws.extendDeadline(heartbeat)
+	heartbeat.SetPongHandler(func(string) error {
+		ws.extendDeadline(heartbeat)
+		return nil
+	})
+	go func() {
+		ticker := time.NewTicker(ws.pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				heartbeat.WriteControl(websocket.PingMessage, nil, time.Now().Add(ws.pongTimeout))
+			}
+		}
+	}()
+}
+
+func (ws *wsManager) extendDeadline(conn Conn) {
+	if heartbeat, ok := conn.(HeartbeatConn); ok && ws.pingInterval > 0 {
+		heartbeat.SetReadDeadline(time.Now().Add(ws.pingInterval + ws.pongTimeout))
+	}
+}
+
+// watchSubscriptions drops the connection when a subscription receives no data over it for
+// the timeout, until the client is closed
+func (client *clientBase) watchSubscriptions(timeout time.Duration) {
+	ticker := time.NewTicker(timeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-client.wsManager.closed:
+			return
+		case <-ticker.C:
+			key, stale := client.chanCache.staleSubscription(timeout, client.wsManager.openSince())
+			if stale {
+				err := fmt.Errorf("%w: no data of %s in %v", models.ErrStaleConnection, key, timeout)
+				client.wsManager.drop(client.wsManager.transportError(err))
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/cryptomkt/cryptomkt-go/v3/decimal"
 	"github.com/cryptomkt/cryptomkt-go/v3/models"
 )
 
@@ -130,6 +131,15 @@ func checkNoNil(field interface{}, name string) error {
 		if v == 0 {
 			return fmt.Errorf("null number: %v", name)
 		}
+	case decimal.Decimal:
+		// a missing decimal is zero
+		if v.IsZero() {
+			return fmt.Errorf("null decimal: %v", name)
+		}
+	case models.Time:
+		if v.IsZero() {
+			return fmt.Errorf("null time: %v", name)
+		}
 	}
 	return nil
 }
@@ -215,7 +225,7 @@ func (obchecker *OBChecker) checkOrderbook(model *models.WSOrderbook) (err error
 	if err != nil {
 		return err
 	}
-	sides := make([][][]string, 2)
+	sides := make([][][]decimal.Decimal, 2)
 	sides[0] = model.Ask
 	sides[1] = model.Bid
 	for _, bookSide := range sides {
@@ -0,0 +1,93 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+//go:generate go run ../internal/mockgen interfaces.go mocks.go
+
+// The interfaces below group the methods of the websocket clients by capability, so code can
+// depend on only what it uses and tests can replace the clients with the mocks of mocks.go
+
+// MarketDataStreams are the public feeds of the MarketDataClient
+type MarketDataStreams interface {
+	SubscribeToTrades(arguments ...args.Argument) (*models.Subscription[models.WSTradeFeed], error)
+	SubscribeToTradesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSTradeFeed], error)
+	SubscribeToCandles(arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error)
+	SubscribeToCandlesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error)
+	SubscribeToConvertedCandles(arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error)
+	SubscribeToConvertedCandlesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error)
+	SubscribeToMiniTicker(arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error)
+	SubscribeToMiniTickerContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error)
+	SubscribeToMiniTickerInBatches(arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error)
+	SubscribeToMiniTickerInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error)
+	SubscribeToTicker(arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error)
+	SubscribeToTickerContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error)
+	SubscribeToTickerInBatches(arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error)
+	SubscribeToTickerInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error)
+	SubscribeToFullOrderbook(arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToFullOrderbookContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToPartialOrderbook(arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToPartialOrderbookContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToPartialOrderbookInBatches(arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToPartialOrderbookInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToOrderbookTop(arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error)
+	SubscribeToOrderbookTopContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error)
+	SubscribeToOrderbookTopInBatches(arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error)
+	SubscribeToOrderbookTopInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error)
+	SubscribeToPriceRates(arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error)
+	SubscribeToPriceRatesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error)
+	SubscribeToPriceRatesInBatches(arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error)
+	SubscribeToPriceRatesInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error)
+	UnsubscribeTo(notificationChannel string)
+	GetActiveSubscriptions(ctx context.Context, arguments ...args.Argument) ([]string, error)
+	Close()
+}
+
+// SpotTrading manages the orders and balances of spot trading, with the feed of order reports
+type SpotTrading interface {
+	GetSpotTradingBalances(ctx context.Context) ([]models.Balance, error)
+	GetSpotTradingBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error)
+	GetActiveSpotOrders(ctx context.Context) ([]models.Report, error)
+	CreateSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Report, error)
+	CreateSpotOrderList(ctx context.Context, arguments ...args.Argument) ([]models.Report, error)
+	CancelSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Report, error)
+	CancelAllSpotOrders(ctx context.Context) ([]models.Order, error)
+	ReplaceSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Report, error)
+	GetTradingCommissions(ctx context.Context) ([]models.TradingCommission, error)
+	GetSpotFee(ctx context.Context, arguments ...args.Argument) (*models.TradingCommission, error)
+	SubscribeToReports() (chan models.Notification[[]models.Report], error)
+	SubscribeToReportsContext(ctx context.Context) (chan models.Notification[[]models.Report], error)
+	UnsubscribeToReports() error
+	UnsubscribeToReportsContext(ctx context.Context) error
+	SubscribeToSpotBalance(arguments ...args.Argument) (chan models.Notification[[]models.Balance], error)
+	SubscribeToSpotBalanceContext(ctx context.Context, arguments ...args.Argument) (chan models.Notification[[]models.Balance], error)
+	UnsubscribeToSpotBalance() error
+	UnsubscribeToSpotBalanceContext(ctx context.Context) error
+	Close()
+}
+
+// WalletManagement reads the wallet, with the feeds of transactions and balances
+type WalletManagement interface {
+	GetWalletBalances(ctx context.Context) ([]models.Balance, error)
+	GetWalletBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error)
+	GetTransactions(ctx context.Context, arguments ...args.Argument) ([]models.Transaction, error)
+	SubscribeToTransactions() (chan models.Notification[models.Transaction], error)
+	SubscribeToTransactionsContext(ctx context.Context) (chan models.Notification[models.Transaction], error)
+	UnsubscribeToTransactions() error
+	UnsubscribeToTransactionsContext(ctx context.Context) error
+	SubscribeToWalletBalances() (chan models.Notification[[]models.Balance], error)
+	SubscribeToWalletBalancesContext(ctx context.Context) (chan models.Notification[[]models.Balance], error)
+	UnsubscribeToWalletBalances() error
+	UnsubscribeToWalletBalancesContext(ctx context.Context) error
+	Close()
+}
+
+var (
+	_ MarketDataStreams = (*MarketDataClient)(nil)
+	_ SpotTrading       = (*SpotTradingClient)(nil)
+	_ WalletManagement  = (*WalletManagementClient)(nil)
+)
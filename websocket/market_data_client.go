@@ -24,22 +24,30 @@ type MarketDataClient struct {
 //
 // Unsubscriptions only closes the relevant channel, and does not make the server stop recieving the subscription data
 // it is safe to unsubscribe to an unsubscribed channel
-func NewMarketDataClient() (*MarketDataClient, error) {
+//
+// Accepts optional client options, as WithRateLimiter
+func NewMarketDataClient(options ...ClientOption) (*MarketDataClient, error) {
+	return NewMarketDataClientContext(context.Background(), options...)
+}
+
+// NewMarketDataClientContext is NewMarketDataClient with a context, which cancels the connection to the exchange
+func NewMarketDataClientContext(ctx context.Context, options ...ClientOption) (*MarketDataClient, error) {
+	config := newClientConfig(options)
 	client := &MarketDataClient{
 		clientBase: clientBase{
-			wsManager: newWSManager("/api/3/ws/public"),
-			chanCache: newChanCache(),
-			window:    0,
+			wsManager:           newWSManager("/api/3/ws/public", config),
+			chanCache:           newChanCache(),
+			window:              0,
+			rateLimiter:         config.rateLimiter,
+			clock:               config.clock,
+			subscriptionTimeout: config.subscriptionTimeout,
 		},
 	}
 
-	// connect to streaming
-	err := client.wsManager.connect()
-	if err != nil {
-		return nil, fmt.Errorf("error in websocket client connection: %s", err)
+	// connect to streaming and handle incomming data
+	if err := client.connect(ctx); err != nil {
+		return nil, fmt.Errorf("error in websocket client connection: %w", err)
 	}
-	// handle incomming data
-	go client.handle(client.wsManager.rcv)
 	return client, nil
 }
 
@@ -52,6 +60,7 @@ type channelSubscriptionResponse struct {
 }
 
 func (client *MarketDataClient) doChannelSubscription(
+	ctx context.Context,
 	method string,
 	subscriptionCh string,
 	params map[string]interface{},
@@ -59,8 +68,8 @@ func (client *MarketDataClient) doChannelSubscription(
 	ch      chan []byte
 	symbols []string
 }, error) {
-	if !client.wsManager.isOpen {
-		return nil, fmt.Errorf("CryptomarketSDKError: websocket connection closed")
+	if !client.wsManager.connected() {
+		return nil, errConnectionClosed()
 	}
 	ch := make(chan []byte, 1)
 	id := client.chanCache.saveCh(ch, 1)
@@ -73,24 +82,36 @@ func (client *MarketDataClient) doChannelSubscription(
 	data, err := json.Marshal(notification)
 	if err != nil {
 		client.chanCache.closeAndRemoveCh(id)
-		return nil, fmt.Errorf("CryptomarketSDKError: invalid notification: %v", err)
+		return nil, &models.SDKError{Message: "invalid notification", Err: err}
 	}
 	key := subscriptionCh
-	var dataOut chan []byte
-	if ch, ok := client.chanCache.getSubscriptionChan(key); ok {
-		dataOut = ch
-	} else {
+	dataOut, reused := client.chanCache.getSubscriptionChan(key)
+	if !reused {
 		dataOut = make(chan []byte, 1)
 	}
 	client.chanCache.saveSubscriptionCh(key, dataOut)
-	client.wsManager.snd <- data
-	data = <-ch
+	// a failed subscription only removes the channel it created, not one of previous subscriptions
+	undo := func() {
+		if !reused {
+			client.chanCache.removeSubscriptionCh(key, dataOut)
+		}
+	}
+	if err := client.sendRequest(ctx, id, data); err != nil {
+		undo()
+		return nil, err
+	}
+	data, err = client.waitResponse(ctx, id, ch)
+	if err != nil {
+		undo()
+		return nil, err
+	}
 	var resp channelSubscriptionResponse
 	json.Unmarshal(data, &resp)
 	if resp.Error != nil {
-		close(dataOut)
-		return nil, fmt.Errorf("CryptomarketAPIError: %v", resp.Error)
+		undo()
+		return nil, resp.Error
 	}
+	client.chanCache.saveSubscriptionRequest(key, subscriptionRequest{method: method, channel: subscriptionCh, params: params})
 	return &(struct {
 		ch      chan []byte
 		symbols []string
@@ -113,6 +134,10 @@ func convertChan[ft models.FeedType](dataCh chan []byte) chan models.Notificatio
 			Data     *ft
 		}
 		for data := range dataCh {
+			if isResyncedFrame(data) {
+				notificationCh <- models.Notification[ft]{NotificationType: args.NotificationResynced}
+				continue
+			}
 			resp.Snapshot = nil
 			resp.Update = nil
 			resp.Data = nil
@@ -142,8 +167,8 @@ func (client *MarketDataClient) GetActiveSubscriptions(
 	if err != nil {
 		return nil, err
 	}
-	if !client.wsManager.isOpen {
-		return nil, fmt.Errorf("CryptomarketSDKError: websocket connection closed")
+	if !client.wsManager.connected() {
+		return nil, errConnectionClosed()
 	}
 	ch := make(chan []byte, 1)
 	id := client.chanCache.saveCh(ch, 1)
@@ -160,29 +185,29 @@ func (client *MarketDataClient) GetActiveSubscriptions(
 	data, err := json.Marshal(notificationWithChannel)
 	if err != nil {
 		client.chanCache.closeAndRemoveCh(id)
-		return nil, fmt.Errorf("CryptomarketSDKError: invalid notification: %v", err)
+		return nil, &models.SDKError{Message: "invalid notification", Err: err}
 	}
-	client.wsManager.snd <- data
-	select {
-	case <-ctx.Done():
-		client.chanCache.closeAndRemoveCh(id)
-		return nil, ctx.Err()
-	case data := <-ch:
-		var resp struct {
-			Error *models.APIError
-		}
-		json.Unmarshal(data, &resp)
-		if resp.Error != nil {
-			return nil, fmt.Errorf("CryptomarketAPIError: %v", resp.Error)
-		}
-		var response struct {
-			Result struct {
-				Subscriptions []string
-			}
+	if err := client.sendRequest(ctx, id, data); err != nil {
+		return nil, err
+	}
+	data, err = client.waitResponse(ctx, id, ch)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Error *models.APIError
+	}
+	json.Unmarshal(data, &resp)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var response struct {
+		Result struct {
+			Subscriptions []string
 		}
-		json.Unmarshal(data, &response)
-		return response.Result.Subscriptions, nil
 	}
+	json.Unmarshal(data, &response)
+	return response.Result.Subscriptions, nil
 }
 
 func addAsteriscIfNoSymbols(params *map[string]interface{}) {
@@ -215,6 +240,14 @@ func addAsteriscIfMissingArg(params *map[string]interface{}, argName string) {
 //	Limit(int64)  // Number of historical entries returned in the first feed. Min is 0. Max is 1000. Default is 0
 func (client *MarketDataClient) SubscribeToTrades(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.WSTradeFeed], err error) {
+	return client.SubscribeToTradesContext(context.Background(), arguments...)
+}
+
+// SubscribeToTradesContext is SubscribeToTrades with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToTradesContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.WSTradeFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSymbols)
 	if err != nil {
@@ -222,6 +255,7 @@ func (client *MarketDataClient) SubscribeToTrades(
 	}
 	channel := internal.ChannelTrades
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -253,6 +287,14 @@ func (client *MarketDataClient) SubscribeToTrades(
 //	Limit(int64)  // Number of historical entries returned in the first feed. Min is 0. Max is 1000. Default is 0
 func (client *MarketDataClient) SubscribeToCandles(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.WSCandleFeed], err error) {
+	return client.SubscribeToCandlesContext(context.Background(), arguments...)
+}
+
+// SubscribeToCandlesContext is SubscribeToCandles with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToCandlesContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.WSCandleFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSymbols, internal.ArgNamePeriod)
 	if err != nil {
@@ -260,6 +302,7 @@ func (client *MarketDataClient) SubscribeToCandles(
 	}
 	channel := fmt.Sprintf(internal.ChannelCandles, params[internal.ArgNamePeriod])
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -296,6 +339,14 @@ func (client *MarketDataClient) SubscribeToCandles(
 //	Limit(int)  // Optional. Prices per currency pair. Defaul is 10. Min is 1. Max is 1000
 func (client *MarketDataClient) SubscribeToConvertedCandles(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.WSCandleFeed], err error) {
+	return client.SubscribeToConvertedCandlesContext(context.Background(), arguments...)
+}
+
+// SubscribeToConvertedCandlesContext is SubscribeToConvertedCandles with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToConvertedCandlesContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.WSCandleFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNamePeriod, internal.ArgNameTargetCurrency, internal.ArgNameSymbols)
 	if err != nil {
@@ -303,6 +354,7 @@ func (client *MarketDataClient) SubscribeToConvertedCandles(
 	}
 	channel := fmt.Sprintf(internal.ChannelConvertedCandles, params[internal.ArgNamePeriod])
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -333,6 +385,14 @@ func (client *MarketDataClient) SubscribeToConvertedCandles(
 //	Symbols([]string)  // Optional. A list of symbol ids
 func (client *MarketDataClient) SubscribeToMiniTicker(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.MiniTickerFeed], err error) {
+	return client.SubscribeToMiniTickerContext(context.Background(), arguments...)
+}
+
+// SubscribeToMiniTickerContext is SubscribeToMiniTicker with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToMiniTickerContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.MiniTickerFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed)
 	if err != nil {
@@ -341,6 +401,7 @@ func (client *MarketDataClient) SubscribeToMiniTicker(
 	addAsteriscIfNoSymbols(&params)
 	channel := fmt.Sprintf(internal.ChannelMiniTicker, params[internal.ArgNameSpeed])
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -371,6 +432,14 @@ func (client *MarketDataClient) SubscribeToMiniTicker(
 //	Symbols([]string)  // Optional. A list of symbol ids
 func (client *MarketDataClient) SubscribeToMiniTickerInBatches(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.MiniTickerFeed], err error) {
+	return client.SubscribeToMiniTickerInBatchesContext(context.Background(), arguments...)
+}
+
+// SubscribeToMiniTickerInBatchesContext is SubscribeToMiniTickerInBatches with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToMiniTickerInBatchesContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.MiniTickerFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed)
 	if err != nil {
@@ -379,6 +448,7 @@ func (client *MarketDataClient) SubscribeToMiniTickerInBatches(
 	addAsteriscIfNoSymbols(&params)
 	channel := fmt.Sprintf(internal.ChannelMiniTickerInBatch, params[internal.ArgNameSpeed])
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -409,6 +479,14 @@ func (client *MarketDataClient) SubscribeToMiniTickerInBatches(
 //	Symbols([]string)  // Optional. A list of symbol ids
 func (client *MarketDataClient) SubscribeToTicker(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.WSTickerFeed], err error) {
+	return client.SubscribeToTickerContext(context.Background(), arguments...)
+}
+
+// SubscribeToTickerContext is SubscribeToTicker with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToTickerContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.WSTickerFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed)
 	if err != nil {
@@ -417,6 +495,7 @@ func (client *MarketDataClient) SubscribeToTicker(
 	addAsteriscIfNoSymbols(&params)
 	channel := fmt.Sprintf(internal.ChannelTicker, params[internal.ArgNameSpeed])
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -447,6 +526,14 @@ func (client *MarketDataClient) SubscribeToTicker(
 //	Symbols([]string)  // Optional. A list of symbol ids
 func (client *MarketDataClient) SubscribeToTickerInBatches(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.WSTickerFeed], err error) {
+	return client.SubscribeToTickerInBatchesContext(context.Background(), arguments...)
+}
+
+// SubscribeToTickerInBatchesContext is SubscribeToTickerInBatches with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToTickerInBatchesContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.WSTickerFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed)
 	if err != nil {
@@ -455,6 +542,7 @@ func (client *MarketDataClient) SubscribeToTickerInBatches(
 	addAsteriscIfNoSymbols(&params)
 	channel := fmt.Sprintf(internal.ChannelTickerInBatch, params[internal.ArgNameSpeed])
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -484,6 +572,14 @@ func (client *MarketDataClient) SubscribeToTickerInBatches(
 //	Symbols([]string)  // Optional. A list of symbol ids
 func (client *MarketDataClient) SubscribeToFullOrderbook(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.WSOrderbookFeed], err error) {
+	return client.SubscribeToFullOrderbookContext(context.Background(), arguments...)
+}
+
+// SubscribeToFullOrderbookContext is SubscribeToFullOrderbook with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToFullOrderbookContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.WSOrderbookFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSymbols)
 	if err != nil {
@@ -491,6 +587,7 @@ func (client *MarketDataClient) SubscribeToFullOrderbook(
 	}
 	channel := internal.ChannelOrderBookFull
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -522,6 +619,14 @@ func (client *MarketDataClient) SubscribeToFullOrderbook(
 //	Symbols([]string)  // Optional. A list of symbol ids
 func (client *MarketDataClient) SubscribeToPartialOrderbook(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.WSOrderbookFeed], err error) {
+	return client.SubscribeToPartialOrderbookContext(context.Background(), arguments...)
+}
+
+// SubscribeToPartialOrderbookContext is SubscribeToPartialOrderbook with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToPartialOrderbookContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.WSOrderbookFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed)
 	if err != nil {
@@ -530,6 +635,7 @@ func (client *MarketDataClient) SubscribeToPartialOrderbook(
 	addAsteriscIfNoSymbols(&params)
 	channel := fmt.Sprintf(internal.ChannelOrderbookPartial, params[internal.ArgNameDepth], params[internal.ArgNameSpeed])
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -559,6 +665,14 @@ func (client *MarketDataClient) SubscribeToPartialOrderbook(
 //	Symbols([]string)  // Optional. A list of symbol ids
 func (client *MarketDataClient) SubscribeToPartialOrderbookInBatches(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.WSOrderbookFeed], err error) {
+	return client.SubscribeToPartialOrderbookInBatchesContext(context.Background(), arguments...)
+}
+
+// SubscribeToPartialOrderbookInBatchesContext is SubscribeToPartialOrderbookInBatches with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToPartialOrderbookInBatchesContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.WSOrderbookFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed)
 	if err != nil {
@@ -571,6 +685,7 @@ func (client *MarketDataClient) SubscribeToPartialOrderbookInBatches(
 		params[internal.ArgNameSpeed],
 	)
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -599,6 +714,14 @@ func (client *MarketDataClient) SubscribeToPartialOrderbookInBatches(
 //	Symbols([]string)  // Optional. A list of symbol ids
 func (client *MarketDataClient) SubscribeToOrderbookTop(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.OrderbookTopFeed], err error) {
+	return client.SubscribeToOrderbookTopContext(context.Background(), arguments...)
+}
+
+// SubscribeToOrderbookTopContext is SubscribeToOrderbookTop with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToOrderbookTopContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.OrderbookTopFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed)
 	if err != nil {
@@ -607,6 +730,7 @@ func (client *MarketDataClient) SubscribeToOrderbookTop(
 	addAsteriscIfNoSymbols(&params)
 	channel := fmt.Sprintf(internal.ChannelOrderbookTop, params[internal.ArgNameSpeed])
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -635,6 +759,14 @@ func (client *MarketDataClient) SubscribeToOrderbookTop(
 //	Symbols([]string)  // Optional. A list of symbol ids
 func (client *MarketDataClient) SubscribeToOrderbookTopInBatches(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.OrderbookTopFeed], err error) {
+	return client.SubscribeToOrderbookTopInBatchesContext(context.Background(), arguments...)
+}
+
+// SubscribeToOrderbookTopInBatchesContext is SubscribeToOrderbookTopInBatches with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToOrderbookTopInBatchesContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.OrderbookTopFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed)
 	if err != nil {
@@ -643,6 +775,7 @@ func (client *MarketDataClient) SubscribeToOrderbookTopInBatches(
 	addAsteriscIfNoSymbols(&params)
 	channel := fmt.Sprintf(internal.ChannelOrderbookTopInBatch, params[internal.ArgNameSpeed])
 	response, err := client.doChannelSubscription(
+		ctx,
 		methodSubscribe,
 		channel,
 		params,
@@ -670,6 +803,14 @@ func (client *MarketDataClient) SubscribeToOrderbookTopInBatches(
 //	TargetCurrency(string) quote currency for the price rates
 func (client *MarketDataClient) SubscribeToPriceRates(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.PriceFeed], err error) {
+	return client.SubscribeToPriceRatesContext(context.Background(), arguments...)
+}
+
+// SubscribeToPriceRatesContext is SubscribeToPriceRates with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToPriceRatesContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.PriceFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed, internal.ArgNameTargetCurrency)
 	if err != nil {
@@ -677,7 +818,7 @@ func (client *MarketDataClient) SubscribeToPriceRates(
 	}
 	addAsteriscIfNoCurrencies(&params)
 	subscriptionCh := fmt.Sprintf(internal.ChannelPriceRates, params[internal.ArgNameSpeed])
-	response, err := client.doChannelSubscription(methodSubscribe, subscriptionCh, params)
+	response, err := client.doChannelSubscription(ctx, methodSubscribe, subscriptionCh, params)
 	if err != nil {
 		return nil, err
 	}
@@ -703,6 +844,14 @@ func (client *MarketDataClient) SubscribeToPriceRates(
 //	TargetCurrency(string) quote currency for the price rates
 func (client *MarketDataClient) SubscribeToPriceRatesInBatches(
 	arguments ...args.Argument,
+) (subscription *models.Subscription[models.PriceFeed], err error) {
+	return client.SubscribeToPriceRatesInBatchesContext(context.Background(), arguments...)
+}
+
+// SubscribeToPriceRatesInBatchesContext is SubscribeToPriceRatesInBatches with a context, which cancels the wait of the response of the exchange
+func (client *MarketDataClient) SubscribeToPriceRatesInBatchesContext(
+	ctx context.Context,
+	arguments ...args.Argument,
 ) (subscription *models.Subscription[models.PriceFeed], err error) {
 	params, err := args.BuildParams(arguments, internal.ArgNameSpeed, internal.ArgNameTargetCurrency)
 	if err != nil {
@@ -710,7 +859,7 @@ func (client *MarketDataClient) SubscribeToPriceRatesInBatches(
 	}
 	addAsteriscIfNoCurrencies(&params)
 	subscriptionCh := fmt.Sprintf(internal.ChannelPriceRatesInBatches, params[internal.ArgNameSpeed])
-	response, err := client.doChannelSubscription(methodSubscribe, subscriptionCh, params)
+	response, err := client.doChannelSubscription(ctx, methodSubscribe, subscriptionCh, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1,5 +1,7 @@
 package websocket
 
+import "github.com/cryptomkt/cryptomkt-go/v3/ratelimit"
+
 const (
 	methodSubscriptions = "subscriptions"
 	methodSubscribe     = "subscribe"
@@ -72,3 +74,18 @@ func getSubscriptionKey(response wsResponse) (key string, ok bool) {
 	}
 	return "", false
 }
+
+var tradingMethods = map[string]bool{
+	methodCreateSpotOrder:     true,
+	methodCreateSpotOrderList: true,
+	methodCancelSpotOrder:     true,
+	methodCancelSpotOrders:    true,
+	methodReplaceSpotOrder:    true,
+}
+
+func rateLimitGroup(method string) ratelimit.Group {
+	if tradingMethods[method] {
+		return ratelimit.GroupTrading
+	}
+	return ratelimit.GroupOther
+}
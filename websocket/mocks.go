@@ -0,0 +1,643 @@
+// Code generated by mockgen from interfaces.go. DO NOT EDIT.
+
+package websocket
+
+import (
+	"context"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+func errNotMocked(method string) error {
+	return &models.SDKError{Message: method + " is not mocked"}
+}
+
+// MockMarketDataStreams is a mock of MarketDataStreams. Each method calls the field of its name with the Func suffix,
+// and fails as not mocked if the field is nil
+type MockMarketDataStreams struct {
+	SubscribeToTradesFunc                           func(arguments ...args.Argument) (*models.Subscription[models.WSTradeFeed], error)
+	SubscribeToTradesContextFunc                    func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSTradeFeed], error)
+	SubscribeToCandlesFunc                          func(arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error)
+	SubscribeToCandlesContextFunc                   func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error)
+	SubscribeToConvertedCandlesFunc                 func(arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error)
+	SubscribeToConvertedCandlesContextFunc          func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error)
+	SubscribeToMiniTickerFunc                       func(arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error)
+	SubscribeToMiniTickerContextFunc                func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error)
+	SubscribeToMiniTickerInBatchesFunc              func(arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error)
+	SubscribeToMiniTickerInBatchesContextFunc       func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error)
+	SubscribeToTickerFunc                           func(arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error)
+	SubscribeToTickerContextFunc                    func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error)
+	SubscribeToTickerInBatchesFunc                  func(arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error)
+	SubscribeToTickerInBatchesContextFunc           func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error)
+	SubscribeToFullOrderbookFunc                    func(arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToFullOrderbookContextFunc             func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToPartialOrderbookFunc                 func(arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToPartialOrderbookContextFunc          func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToPartialOrderbookInBatchesFunc        func(arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToPartialOrderbookInBatchesContextFunc func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error)
+	SubscribeToOrderbookTopFunc                     func(arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error)
+	SubscribeToOrderbookTopContextFunc              func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error)
+	SubscribeToOrderbookTopInBatchesFunc            func(arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error)
+	SubscribeToOrderbookTopInBatchesContextFunc     func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error)
+	SubscribeToPriceRatesFunc                       func(arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error)
+	SubscribeToPriceRatesContextFunc                func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error)
+	SubscribeToPriceRatesInBatchesFunc              func(arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error)
+	SubscribeToPriceRatesInBatchesContextFunc       func(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error)
+	UnsubscribeToFunc                               func(notificationChannel string)
+	GetActiveSubscriptionsFunc                      func(ctx context.Context, arguments ...args.Argument) ([]string, error)
+	CloseFunc                                       func()
+}
+
+// SubscribeToTrades calls SubscribeToTradesFunc
+func (mock *MockMarketDataStreams) SubscribeToTrades(arguments ...args.Argument) (*models.Subscription[models.WSTradeFeed], error) {
+	if mock.SubscribeToTradesFunc == nil {
+		var r0 *models.Subscription[models.WSTradeFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToTrades")
+	}
+	return mock.SubscribeToTradesFunc(arguments...)
+}
+
+// SubscribeToTradesContext calls SubscribeToTradesContextFunc
+func (mock *MockMarketDataStreams) SubscribeToTradesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSTradeFeed], error) {
+	if mock.SubscribeToTradesContextFunc == nil {
+		var r0 *models.Subscription[models.WSTradeFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToTradesContext")
+	}
+	return mock.SubscribeToTradesContextFunc(ctx, arguments...)
+}
+
+// SubscribeToCandles calls SubscribeToCandlesFunc
+func (mock *MockMarketDataStreams) SubscribeToCandles(arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error) {
+	if mock.SubscribeToCandlesFunc == nil {
+		var r0 *models.Subscription[models.WSCandleFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToCandles")
+	}
+	return mock.SubscribeToCandlesFunc(arguments...)
+}
+
+// SubscribeToCandlesContext calls SubscribeToCandlesContextFunc
+func (mock *MockMarketDataStreams) SubscribeToCandlesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error) {
+	if mock.SubscribeToCandlesContextFunc == nil {
+		var r0 *models.Subscription[models.WSCandleFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToCandlesContext")
+	}
+	return mock.SubscribeToCandlesContextFunc(ctx, arguments...)
+}
+
+// SubscribeToConvertedCandles calls SubscribeToConvertedCandlesFunc
+func (mock *MockMarketDataStreams) SubscribeToConvertedCandles(arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error) {
+	if mock.SubscribeToConvertedCandlesFunc == nil {
+		var r0 *models.Subscription[models.WSCandleFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToConvertedCandles")
+	}
+	return mock.SubscribeToConvertedCandlesFunc(arguments...)
+}
+
+// SubscribeToConvertedCandlesContext calls SubscribeToConvertedCandlesContextFunc
+func (mock *MockMarketDataStreams) SubscribeToConvertedCandlesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSCandleFeed], error) {
+	if mock.SubscribeToConvertedCandlesContextFunc == nil {
+		var r0 *models.Subscription[models.WSCandleFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToConvertedCandlesContext")
+	}
+	return mock.SubscribeToConvertedCandlesContextFunc(ctx, arguments...)
+}
+
+// SubscribeToMiniTicker calls SubscribeToMiniTickerFunc
+func (mock *MockMarketDataStreams) SubscribeToMiniTicker(arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error) {
+	if mock.SubscribeToMiniTickerFunc == nil {
+		var r0 *models.Subscription[models.MiniTickerFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToMiniTicker")
+	}
+	return mock.SubscribeToMiniTickerFunc(arguments...)
+}
+
+// SubscribeToMiniTickerContext calls SubscribeToMiniTickerContextFunc
+func (mock *MockMarketDataStreams) SubscribeToMiniTickerContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error) {
+	if mock.SubscribeToMiniTickerContextFunc == nil {
+		var r0 *models.Subscription[models.MiniTickerFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToMiniTickerContext")
+	}
+	return mock.SubscribeToMiniTickerContextFunc(ctx, arguments...)
+}
+
+// SubscribeToMiniTickerInBatches calls SubscribeToMiniTickerInBatchesFunc
+func (mock *MockMarketDataStreams) SubscribeToMiniTickerInBatches(arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error) {
+	if mock.SubscribeToMiniTickerInBatchesFunc == nil {
+		var r0 *models.Subscription[models.MiniTickerFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToMiniTickerInBatches")
+	}
+	return mock.SubscribeToMiniTickerInBatchesFunc(arguments...)
+}
+
+// SubscribeToMiniTickerInBatchesContext calls SubscribeToMiniTickerInBatchesContextFunc
+func (mock *MockMarketDataStreams) SubscribeToMiniTickerInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.MiniTickerFeed], error) {
+	if mock.SubscribeToMiniTickerInBatchesContextFunc == nil {
+		var r0 *models.Subscription[models.MiniTickerFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToMiniTickerInBatchesContext")
+	}
+	return mock.SubscribeToMiniTickerInBatchesContextFunc(ctx, arguments...)
+}
+
+// SubscribeToTicker calls SubscribeToTickerFunc
+func (mock *MockMarketDataStreams) SubscribeToTicker(arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error) {
+	if mock.SubscribeToTickerFunc == nil {
+		var r0 *models.Subscription[models.WSTickerFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToTicker")
+	}
+	return mock.SubscribeToTickerFunc(arguments...)
+}
+
+// SubscribeToTickerContext calls SubscribeToTickerContextFunc
+func (mock *MockMarketDataStreams) SubscribeToTickerContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error) {
+	if mock.SubscribeToTickerContextFunc == nil {
+		var r0 *models.Subscription[models.WSTickerFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToTickerContext")
+	}
+	return mock.SubscribeToTickerContextFunc(ctx, arguments...)
+}
+
+// SubscribeToTickerInBatches calls SubscribeToTickerInBatchesFunc
+func (mock *MockMarketDataStreams) SubscribeToTickerInBatches(arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error) {
+	if mock.SubscribeToTickerInBatchesFunc == nil {
+		var r0 *models.Subscription[models.WSTickerFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToTickerInBatches")
+	}
+	return mock.SubscribeToTickerInBatchesFunc(arguments...)
+}
+
+// SubscribeToTickerInBatchesContext calls SubscribeToTickerInBatchesContextFunc
+func (mock *MockMarketDataStreams) SubscribeToTickerInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSTickerFeed], error) {
+	if mock.SubscribeToTickerInBatchesContextFunc == nil {
+		var r0 *models.Subscription[models.WSTickerFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToTickerInBatchesContext")
+	}
+	return mock.SubscribeToTickerInBatchesContextFunc(ctx, arguments...)
+}
+
+// SubscribeToFullOrderbook calls SubscribeToFullOrderbookFunc
+func (mock *MockMarketDataStreams) SubscribeToFullOrderbook(arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error) {
+	if mock.SubscribeToFullOrderbookFunc == nil {
+		var r0 *models.Subscription[models.WSOrderbookFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToFullOrderbook")
+	}
+	return mock.SubscribeToFullOrderbookFunc(arguments...)
+}
+
+// SubscribeToFullOrderbookContext calls SubscribeToFullOrderbookContextFunc
+func (mock *MockMarketDataStreams) SubscribeToFullOrderbookContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error) {
+	if mock.SubscribeToFullOrderbookContextFunc == nil {
+		var r0 *models.Subscription[models.WSOrderbookFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToFullOrderbookContext")
+	}
+	return mock.SubscribeToFullOrderbookContextFunc(ctx, arguments...)
+}
+
+// SubscribeToPartialOrderbook calls SubscribeToPartialOrderbookFunc
+func (mock *MockMarketDataStreams) SubscribeToPartialOrderbook(arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error) {
+	if mock.SubscribeToPartialOrderbookFunc == nil {
+		var r0 *models.Subscription[models.WSOrderbookFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToPartialOrderbook")
+	}
+	return mock.SubscribeToPartialOrderbookFunc(arguments...)
+}
+
+// SubscribeToPartialOrderbookContext calls SubscribeToPartialOrderbookContextFunc
+func (mock *MockMarketDataStreams) SubscribeToPartialOrderbookContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error) {
+	if mock.SubscribeToPartialOrderbookContextFunc == nil {
+		var r0 *models.Subscription[models.WSOrderbookFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToPartialOrderbookContext")
+	}
+	return mock.SubscribeToPartialOrderbookContextFunc(ctx, arguments...)
+}
+
+// SubscribeToPartialOrderbookInBatches calls SubscribeToPartialOrderbookInBatchesFunc
+func (mock *MockMarketDataStreams) SubscribeToPartialOrderbookInBatches(arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error) {
+	if mock.SubscribeToPartialOrderbookInBatchesFunc == nil {
+		var r0 *models.Subscription[models.WSOrderbookFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToPartialOrderbookInBatches")
+	}
+	return mock.SubscribeToPartialOrderbookInBatchesFunc(arguments...)
+}
+
+// SubscribeToPartialOrderbookInBatchesContext calls SubscribeToPartialOrderbookInBatchesContextFunc
+func (mock *MockMarketDataStreams) SubscribeToPartialOrderbookInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.WSOrderbookFeed], error) {
+	if mock.SubscribeToPartialOrderbookInBatchesContextFunc == nil {
+		var r0 *models.Subscription[models.WSOrderbookFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToPartialOrderbookInBatchesContext")
+	}
+	return mock.SubscribeToPartialOrderbookInBatchesContextFunc(ctx, arguments...)
+}
+
+// SubscribeToOrderbookTop calls SubscribeToOrderbookTopFunc
+func (mock *MockMarketDataStreams) SubscribeToOrderbookTop(arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error) {
+	if mock.SubscribeToOrderbookTopFunc == nil {
+		var r0 *models.Subscription[models.OrderbookTopFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToOrderbookTop")
+	}
+	return mock.SubscribeToOrderbookTopFunc(arguments...)
+}
+
+// SubscribeToOrderbookTopContext calls SubscribeToOrderbookTopContextFunc
+func (mock *MockMarketDataStreams) SubscribeToOrderbookTopContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error) {
+	if mock.SubscribeToOrderbookTopContextFunc == nil {
+		var r0 *models.Subscription[models.OrderbookTopFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToOrderbookTopContext")
+	}
+	return mock.SubscribeToOrderbookTopContextFunc(ctx, arguments...)
+}
+
+// SubscribeToOrderbookTopInBatches calls SubscribeToOrderbookTopInBatchesFunc
+func (mock *MockMarketDataStreams) SubscribeToOrderbookTopInBatches(arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error) {
+	if mock.SubscribeToOrderbookTopInBatchesFunc == nil {
+		var r0 *models.Subscription[models.OrderbookTopFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToOrderbookTopInBatches")
+	}
+	return mock.SubscribeToOrderbookTopInBatchesFunc(arguments...)
+}
+
+// SubscribeToOrderbookTopInBatchesContext calls SubscribeToOrderbookTopInBatchesContextFunc
+func (mock *MockMarketDataStreams) SubscribeToOrderbookTopInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.OrderbookTopFeed], error) {
+	if mock.SubscribeToOrderbookTopInBatchesContextFunc == nil {
+		var r0 *models.Subscription[models.OrderbookTopFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToOrderbookTopInBatchesContext")
+	}
+	return mock.SubscribeToOrderbookTopInBatchesContextFunc(ctx, arguments...)
+}
+
+// SubscribeToPriceRates calls SubscribeToPriceRatesFunc
+func (mock *MockMarketDataStreams) SubscribeToPriceRates(arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error) {
+	if mock.SubscribeToPriceRatesFunc == nil {
+		var r0 *models.Subscription[models.PriceFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToPriceRates")
+	}
+	return mock.SubscribeToPriceRatesFunc(arguments...)
+}
+
+// SubscribeToPriceRatesContext calls SubscribeToPriceRatesContextFunc
+func (mock *MockMarketDataStreams) SubscribeToPriceRatesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error) {
+	if mock.SubscribeToPriceRatesContextFunc == nil {
+		var r0 *models.Subscription[models.PriceFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToPriceRatesContext")
+	}
+	return mock.SubscribeToPriceRatesContextFunc(ctx, arguments...)
+}
+
+// SubscribeToPriceRatesInBatches calls SubscribeToPriceRatesInBatchesFunc
+func (mock *MockMarketDataStreams) SubscribeToPriceRatesInBatches(arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error) {
+	if mock.SubscribeToPriceRatesInBatchesFunc == nil {
+		var r0 *models.Subscription[models.PriceFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToPriceRatesInBatches")
+	}
+	return mock.SubscribeToPriceRatesInBatchesFunc(arguments...)
+}
+
+// SubscribeToPriceRatesInBatchesContext calls SubscribeToPriceRatesInBatchesContextFunc
+func (mock *MockMarketDataStreams) SubscribeToPriceRatesInBatchesContext(ctx context.Context, arguments ...args.Argument) (*models.Subscription[models.PriceFeed], error) {
+	if mock.SubscribeToPriceRatesInBatchesContextFunc == nil {
+		var r0 *models.Subscription[models.PriceFeed]
+		return r0, errNotMocked("MockMarketDataStreams.SubscribeToPriceRatesInBatchesContext")
+	}
+	return mock.SubscribeToPriceRatesInBatchesContextFunc(ctx, arguments...)
+}
+
+// UnsubscribeTo calls UnsubscribeToFunc
+func (mock *MockMarketDataStreams) UnsubscribeTo(notificationChannel string) {
+	if mock.UnsubscribeToFunc == nil {
+		return
+	}
+	mock.UnsubscribeToFunc(notificationChannel)
+}
+
+// GetActiveSubscriptions calls GetActiveSubscriptionsFunc
+func (mock *MockMarketDataStreams) GetActiveSubscriptions(ctx context.Context, arguments ...args.Argument) ([]string, error) {
+	if mock.GetActiveSubscriptionsFunc == nil {
+		var r0 []string
+		return r0, errNotMocked("MockMarketDataStreams.GetActiveSubscriptions")
+	}
+	return mock.GetActiveSubscriptionsFunc(ctx, arguments...)
+}
+
+// Close calls CloseFunc
+func (mock *MockMarketDataStreams) Close() {
+	if mock.CloseFunc == nil {
+		return
+	}
+	mock.CloseFunc()
+}
+
+var _ MarketDataStreams = (*MockMarketDataStreams)(nil)
+
+// MockSpotTrading is a mock of SpotTrading. Each method calls the field of its name with the Func suffix,
+// and fails as not mocked if the field is nil
+type MockSpotTrading struct {
+	GetSpotTradingBalancesFunc          func(ctx context.Context) ([]models.Balance, error)
+	GetSpotTradingBalanceOfCurrencyFunc func(ctx context.Context, arguments ...args.Argument) (*models.Balance, error)
+	GetActiveSpotOrdersFunc             func(ctx context.Context) ([]models.Report, error)
+	CreateSpotOrderFunc                 func(ctx context.Context, arguments ...args.Argument) (*models.Report, error)
+	CreateSpotOrderListFunc             func(ctx context.Context, arguments ...args.Argument) ([]models.Report, error)
+	CancelSpotOrderFunc                 func(ctx context.Context, arguments ...args.Argument) (*models.Report, error)
+	CancelAllSpotOrdersFunc             func(ctx context.Context) ([]models.Order, error)
+	ReplaceSpotOrderFunc                func(ctx context.Context, arguments ...args.Argument) (*models.Report, error)
+	GetTradingCommissionsFunc           func(ctx context.Context) ([]models.TradingCommission, error)
+	GetSpotFeeFunc                      func(ctx context.Context, arguments ...args.Argument) (*models.TradingCommission, error)
+	SubscribeToReportsFunc              func() (chan models.Notification[[]models.Report], error)
+	SubscribeToReportsContextFunc       func(ctx context.Context) (chan models.Notification[[]models.Report], error)
+	UnsubscribeToReportsFunc            func() error
+	UnsubscribeToReportsContextFunc     func(ctx context.Context) error
+	SubscribeToSpotBalanceFunc          func(arguments ...args.Argument) (chan models.Notification[[]models.Balance], error)
+	SubscribeToSpotBalanceContextFunc   func(ctx context.Context, arguments ...args.Argument) (chan models.Notification[[]models.Balance], error)
+	UnsubscribeToSpotBalanceFunc        func() error
+	UnsubscribeToSpotBalanceContextFunc func(ctx context.Context) error
+	CloseFunc                           func()
+}
+
+// GetSpotTradingBalances calls GetSpotTradingBalancesFunc
+func (mock *MockSpotTrading) GetSpotTradingBalances(ctx context.Context) ([]models.Balance, error) {
+	if mock.GetSpotTradingBalancesFunc == nil {
+		var r0 []models.Balance
+		return r0, errNotMocked("MockSpotTrading.GetSpotTradingBalances")
+	}
+	return mock.GetSpotTradingBalancesFunc(ctx)
+}
+
+// GetSpotTradingBalanceOfCurrency calls GetSpotTradingBalanceOfCurrencyFunc
+func (mock *MockSpotTrading) GetSpotTradingBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error) {
+	if mock.GetSpotTradingBalanceOfCurrencyFunc == nil {
+		var r0 *models.Balance
+		return r0, errNotMocked("MockSpotTrading.GetSpotTradingBalanceOfCurrency")
+	}
+	return mock.GetSpotTradingBalanceOfCurrencyFunc(ctx, arguments...)
+}
+
+// GetActiveSpotOrders calls GetActiveSpotOrdersFunc
+func (mock *MockSpotTrading) GetActiveSpotOrders(ctx context.Context) ([]models.Report, error) {
+	if mock.GetActiveSpotOrdersFunc == nil {
+		var r0 []models.Report
+		return r0, errNotMocked("MockSpotTrading.GetActiveSpotOrders")
+	}
+	return mock.GetActiveSpotOrdersFunc(ctx)
+}
+
+// CreateSpotOrder calls CreateSpotOrderFunc
+func (mock *MockSpotTrading) CreateSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Report, error) {
+	if mock.CreateSpotOrderFunc == nil {
+		var r0 *models.Report
+		return r0, errNotMocked("MockSpotTrading.CreateSpotOrder")
+	}
+	return mock.CreateSpotOrderFunc(ctx, arguments...)
+}
+
+// CreateSpotOrderList calls CreateSpotOrderListFunc
+func (mock *MockSpotTrading) CreateSpotOrderList(ctx context.Context, arguments ...args.Argument) ([]models.Report, error) {
+	if mock.CreateSpotOrderListFunc == nil {
+		var r0 []models.Report
+		return r0, errNotMocked("MockSpotTrading.CreateSpotOrderList")
+	}
+	return mock.CreateSpotOrderListFunc(ctx, arguments...)
+}
+
+// CancelSpotOrder calls CancelSpotOrderFunc
+func (mock *MockSpotTrading) CancelSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Report, error) {
+	if mock.CancelSpotOrderFunc == nil {
+		var r0 *models.Report
+		return r0, errNotMocked("MockSpotTrading.CancelSpotOrder")
+	}
+	return mock.CancelSpotOrderFunc(ctx, arguments...)
+}
+
+// CancelAllSpotOrders calls CancelAllSpotOrdersFunc
+func (mock *MockSpotTrading) CancelAllSpotOrders(ctx context.Context) ([]models.Order, error) {
+	if mock.CancelAllSpotOrdersFunc == nil {
+		var r0 []models.Order
+		return r0, errNotMocked("MockSpotTrading.CancelAllSpotOrders")
+	}
+	return mock.CancelAllSpotOrdersFunc(ctx)
+}
+
+// ReplaceSpotOrder calls ReplaceSpotOrderFunc
+func (mock *MockSpotTrading) ReplaceSpotOrder(ctx context.Context, arguments ...args.Argument) (*models.Report, error) {
+	if mock.ReplaceSpotOrderFunc == nil {
+		var r0 *models.Report
+		return r0, errNotMocked("MockSpotTrading.ReplaceSpotOrder")
+	}
+	return mock.ReplaceSpotOrderFunc(ctx, arguments...)
+}
+
+// GetTradingCommissions calls GetTradingCommissionsFunc
+func (mock *MockSpotTrading) GetTradingCommissions(ctx context.Context) ([]models.TradingCommission, error) {
+	if mock.GetTradingCommissionsFunc == nil {
+		var r0 []models.TradingCommission
+		return r0, errNotMocked("MockSpotTrading.GetTradingCommissions")
+	}
+	return mock.GetTradingCommissionsFunc(ctx)
+}
+
+// GetSpotFee calls GetSpotFeeFunc
+func (mock *MockSpotTrading) GetSpotFee(ctx context.Context, arguments ...args.Argument) (*models.TradingCommission, error) {
+	if mock.GetSpotFeeFunc == nil {
+		var r0 *models.TradingCommission
+		return r0, errNotMocked("MockSpotTrading.GetSpotFee")
+	}
+	return mock.GetSpotFeeFunc(ctx, arguments...)
+}
+
+// SubscribeToReports calls SubscribeToReportsFunc
+func (mock *MockSpotTrading) SubscribeToReports() (chan models.Notification[[]models.Report], error) {
+	if mock.SubscribeToReportsFunc == nil {
+		var r0 chan models.Notification[[]models.Report]
+		return r0, errNotMocked("MockSpotTrading.SubscribeToReports")
+	}
+	return mock.SubscribeToReportsFunc()
+}
+
+// SubscribeToReportsContext calls SubscribeToReportsContextFunc
+func (mock *MockSpotTrading) SubscribeToReportsContext(ctx context.Context) (chan models.Notification[[]models.Report], error) {
+	if mock.SubscribeToReportsContextFunc == nil {
+		var r0 chan models.Notification[[]models.Report]
+		return r0, errNotMocked("MockSpotTrading.SubscribeToReportsContext")
+	}
+	return mock.SubscribeToReportsContextFunc(ctx)
+}
+
+// UnsubscribeToReports calls UnsubscribeToReportsFunc
+func (mock *MockSpotTrading) UnsubscribeToReports() error {
+	if mock.UnsubscribeToReportsFunc == nil {
+		return errNotMocked("MockSpotTrading.UnsubscribeToReports")
+	}
+	return mock.UnsubscribeToReportsFunc()
+}
+
+// UnsubscribeToReportsContext calls UnsubscribeToReportsContextFunc
+func (mock *MockSpotTrading) UnsubscribeToReportsContext(ctx context.Context) error {
+	if mock.UnsubscribeToReportsContextFunc == nil {
+		return errNotMocked("MockSpotTrading.UnsubscribeToReportsContext")
+	}
+	return mock.UnsubscribeToReportsContextFunc(ctx)
+}
+
+// SubscribeToSpotBalance calls SubscribeToSpotBalanceFunc
+func (mock *MockSpotTrading) SubscribeToSpotBalance(arguments ...args.Argument) (chan models.Notification[[]models.Balance], error) {
+	if mock.SubscribeToSpotBalanceFunc == nil {
+		var r0 chan models.Notification[[]models.Balance]
+		return r0, errNotMocked("MockSpotTrading.SubscribeToSpotBalance")
+	}
+	return mock.SubscribeToSpotBalanceFunc(arguments...)
+}
+
+// SubscribeToSpotBalanceContext calls SubscribeToSpotBalanceContextFunc
+func (mock *MockSpotTrading) SubscribeToSpotBalanceContext(ctx context.Context, arguments ...args.Argument) (chan models.Notification[[]models.Balance], error) {
+	if mock.SubscribeToSpotBalanceContextFunc == nil {
+		var r0 chan models.Notification[[]models.Balance]
+		return r0, errNotMocked("MockSpotTrading.SubscribeToSpotBalanceContext")
+	}
+	return mock.SubscribeToSpotBalanceContextFunc(ctx, arguments...)
+}
+
+// UnsubscribeToSpotBalance calls UnsubscribeToSpotBalanceFunc
+func (mock *MockSpotTrading) UnsubscribeToSpotBalance() error {
+	if mock.UnsubscribeToSpotBalanceFunc == nil {
+		return errNotMocked("MockSpotTrading.UnsubscribeToSpotBalance")
+	}
+	return mock.UnsubscribeToSpotBalanceFunc()
+}
+
+// UnsubscribeToSpotBalanceContext calls UnsubscribeToSpotBalanceContextFunc
+func (mock *MockSpotTrading) UnsubscribeToSpotBalanceContext(ctx context.Context) error {
+	if mock.UnsubscribeToSpotBalanceContextFunc == nil {
+		return errNotMocked("MockSpotTrading.UnsubscribeToSpotBalanceContext")
+	}
+	return mock.UnsubscribeToSpotBalanceContextFunc(ctx)
+}
+
+// Close calls CloseFunc
+func (mock *MockSpotTrading) Close() {
+	if mock.CloseFunc == nil {
+		return
+	}
+	mock.CloseFunc()
+}
+
+var _ SpotTrading = (*MockSpotTrading)(nil)
+
+// MockWalletManagement is a mock of WalletManagement. Each method calls the field of its name with the Func suffix,
+// and fails as not mocked if the field is nil
+type MockWalletManagement struct {
+	GetWalletBalancesFunc                  func(ctx context.Context) ([]models.Balance, error)
+	GetWalletBalanceOfCurrencyFunc         func(ctx context.Context, arguments ...args.Argument) (*models.Balance, error)
+	GetTransactionsFunc                    func(ctx context.Context, arguments ...args.Argument) ([]models.Transaction, error)
+	SubscribeToTransactionsFunc            func() (chan models.Notification[models.Transaction], error)
+	SubscribeToTransactionsContextFunc     func(ctx context.Context) (chan models.Notification[models.Transaction], error)
+	UnsubscribeToTransactionsFunc          func() error
+	UnsubscribeToTransactionsContextFunc   func(ctx context.Context) error
+	SubscribeToWalletBalancesFunc          func() (chan models.Notification[[]models.Balance], error)
+	SubscribeToWalletBalancesContextFunc   func(ctx context.Context) (chan models.Notification[[]models.Balance], error)
+	UnsubscribeToWalletBalancesFunc        func() error
+	UnsubscribeToWalletBalancesContextFunc func(ctx context.Context) error
+	CloseFunc                              func()
+}
+
+// GetWalletBalances calls GetWalletBalancesFunc
+func (mock *MockWalletManagement) GetWalletBalances(ctx context.Context) ([]models.Balance, error) {
+	if mock.GetWalletBalancesFunc == nil {
+		var r0 []models.Balance
+		return r0, errNotMocked("MockWalletManagement.GetWalletBalances")
+	}
+	return mock.GetWalletBalancesFunc(ctx)
+}
+
+// GetWalletBalanceOfCurrency calls GetWalletBalanceOfCurrencyFunc
+func (mock *MockWalletManagement) GetWalletBalanceOfCurrency(ctx context.Context, arguments ...args.Argument) (*models.Balance, error) {
+	if mock.GetWalletBalanceOfCurrencyFunc == nil {
+		var r0 *models.Balance
+		return r0, errNotMocked("MockWalletManagement.GetWalletBalanceOfCurrency")
+	}
+	return mock.GetWalletBalanceOfCurrencyFunc(ctx, arguments...)
+}
+
+// GetTransactions calls GetTransactionsFunc
+func (mock *MockWalletManagement) GetTransactions(ctx context.Context, arguments ...args.Argument) ([]models.Transaction, error) {
+	if mock.GetTransactionsFunc == nil {
+		var r0 []models.Transaction
+		return r0, errNotMocked("MockWalletManagement.GetTransactions")
+	}
+	return mock.GetTransactionsFunc(ctx, arguments...)
+}
+
+// SubscribeToTransactions calls SubscribeToTransactionsFunc
+func (mock *MockWalletManagement) SubscribeToTransactions() (chan models.Notification[models.Transaction], error) {
+	if mock.SubscribeToTransactionsFunc == nil {
+		var r0 chan models.Notification[models.Transaction]
+		return r0, errNotMocked("MockWalletManagement.SubscribeToTransactions")
+	}
+	return mock.SubscribeToTransactionsFunc()
+}
+
+// SubscribeToTransactionsContext calls SubscribeToTransactionsContextFunc
+func (mock *MockWalletManagement) SubscribeToTransactionsContext(ctx context.Context) (chan models.Notification[models.Transaction], error) {
+	if mock.SubscribeToTransactionsContextFunc == nil {
+		var r0 chan models.Notification[models.Transaction]
+		return r0, errNotMocked("MockWalletManagement.SubscribeToTransactionsContext")
+	}
+	return mock.SubscribeToTransactionsContextFunc(ctx)
+}
+
+// UnsubscribeToTransactions calls UnsubscribeToTransactionsFunc
+func (mock *MockWalletManagement) UnsubscribeToTransactions() error {
+	if mock.UnsubscribeToTransactionsFunc == nil {
+		return errNotMocked("MockWalletManagement.UnsubscribeToTransactions")
+	}
+	return mock.UnsubscribeToTransactionsFunc()
+}
+
+// UnsubscribeToTransactionsContext calls UnsubscribeToTransactionsContextFunc
+func (mock *MockWalletManagement) UnsubscribeToTransactionsContext(ctx context.Context) error {
+	if mock.UnsubscribeToTransactionsContextFunc == nil {
+		return errNotMocked("MockWalletManagement.UnsubscribeToTransactionsContext")
+	}
+	return mock.UnsubscribeToTransactionsContextFunc(ctx)
+}
+
+// SubscribeToWalletBalances calls SubscribeToWalletBalancesFunc
+func (mock *MockWalletManagement) SubscribeToWalletBalances() (chan models.Notification[[]models.Balance], error) {
+	if mock.SubscribeToWalletBalancesFunc == nil {
+		var r0 chan models.Notification[[]models.Balance]
+		return r0, errNotMocked("MockWalletManagement.SubscribeToWalletBalances")
+	}
+	return mock.SubscribeToWalletBalancesFunc()
+}
+
+// SubscribeToWalletBalancesContext calls SubscribeToWalletBalancesContextFunc
+func (mock *MockWalletManagement) SubscribeToWalletBalancesContext(ctx context.Context) (chan models.Notification[[]models.Balance], error) {
+	if mock.SubscribeToWalletBalancesContextFunc == nil {
+		var r0 chan models.Notification[[]models.Balance]
+		return r0, errNotMocked("MockWalletManagement.SubscribeToWalletBalancesContext")
+	}
+	return mock.SubscribeToWalletBalancesContextFunc(ctx)
+}
+
+// UnsubscribeToWalletBalances calls UnsubscribeToWalletBalancesFunc
+func (mock *MockWalletManagement) UnsubscribeToWalletBalances() error {
+	if mock.UnsubscribeToWalletBalancesFunc == nil {
+		return errNotMocked("MockWalletManagement.UnsubscribeToWalletBalances")
+	}
+	return mock.UnsubscribeToWalletBalancesFunc()
+}
+
+// UnsubscribeToWalletBalancesContext calls UnsubscribeToWalletBalancesContextFunc
+func (mock *MockWalletManagement) UnsubscribeToWalletBalancesContext(ctx context.Context) error {
+	if mock.UnsubscribeToWalletBalancesContextFunc == nil {
+		return errNotMocked("MockWalletManagement.UnsubscribeToWalletBalancesContext")
+	}
+	return mock.UnsubscribeToWalletBalancesContextFunc(ctx)
+}
+
+// Close calls CloseFunc
+func (mock *MockWalletManagement) Close() {
+	if mock.CloseFunc == nil {
+		return
+	}
+	mock.CloseFunc()
+}
+
+var _ WalletManagement = (*MockWalletManagement)(nil)
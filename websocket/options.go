@@ -0,0 +1,158 @@
+package websocket
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
+	"github.com/cryptomkt/cryptomkt-go/v3/ratelimit"
+	"github.com/gorilla/websocket"
+)
+
+const defaultBaseURL = "wss://api.exchange.cryptomkt.com"
+
+// ClientOption configures a websocket client on creation
+type ClientOption func(*clientConfig)
+
+type clientConfig struct {
+	baseURL     string
+	dialer      *websocket.Dialer
+	header      http.Header
+	readLimit   int64
+	apiKey      string
+	apiSecret   string
+	window      int
+	rateLimiter *ratelimit.Limiter
+	signer      auth.Signer
+	clock       *auth.Clock
+	connector   Connector
+	// reconnectPolicy is nil when the clients do not reconnect
+	reconnectPolicy     *ReconnectPolicy
+	pingInterval        time.Duration
+	pongTimeout         time.Duration
+	subscriptionTimeout time.Duration
+	errorHandler        func(error)
+	// orderValidator only applies to the SpotTradingClient
+	orderValidator OrderValidator
+}
+
+// WithBaseURL sets the base url of the websocket api, without the stream paths.
+// Useful to target a sandbox, a local fake server or a recording proxy.
+// Default is wss://api.exchange.cryptomkt.com
+func WithBaseURL(baseURL string) ClientOption {
+	return func(config *clientConfig) {
+		config.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithDialer sets the gorilla/websocket dialer opening the connections, as one with a proxy,
+// a TLS config or a handshake timeout. Ignored if a connector is given with WithConnector
+func WithDialer(dialer *websocket.Dialer) ClientOption {
+	return func(config *clientConfig) {
+		config.dialer = dialer
+	}
+}
+
+// WithHeaders adds headers to the handshake of the connections, as the authorization of a proxy.
+// Ignored if a connector is given with WithConnector
+func WithHeaders(header http.Header) ClientOption {
+	return func(config *clientConfig) {
+		if config.header == nil {
+			config.header = make(http.Header)
+		}
+		for key, values := range header {
+			for _, value := range values {
+				config.header.Add(key, value)
+			}
+		}
+	}
+}
+
+// WithReadLimit sets the maximum size in bytes of a message from the exchange. A bigger message
+// closes the connection. Only applies to connections with a SetReadLimit method, as the ones of gorilla/websocket
+func WithReadLimit(limit int64) ClientOption {
+	return func(config *clientConfig) {
+		config.readLimit = limit
+	}
+}
+
+// WithCredentials sets the api key and api secret of the login of authenticated clients.
+// The api secret is ignored if a signer is given with WithSigner
+func WithCredentials(apiKey, apiSecret string) ClientOption {
+	return func(config *clientConfig) {
+		config.apiKey = apiKey
+		config.apiSecret = apiSecret
+	}
+}
+
+// WithWindow sets the window of execution of the requests of authenticated clients in milliseconds.
+// Max is 60_000. Use 0 for the default window (10 seconds)
+func WithWindow(window int) ClientOption {
+	return func(config *clientConfig) {
+		config.window = window
+	}
+}
+
+// WithRateLimiter throttles the requests of the client with the given limiter.
+// Share it with the rest.Client trading on the same account so both count against the same budget.
+func WithRateLimiter(limiter *ratelimit.Limiter) ClientOption {
+	return func(config *clientConfig) {
+		config.rateLimiter = limiter
+	}
+}
+
+// WithSigner sets the signer of the login of authenticated clients, instead of the api secret.
+// Lets the api secret live outside of the process, as in a remote signing service.
+func WithSigner(signer auth.Signer) ClientOption {
+	return func(config *clientConfig) {
+		config.signer = signer
+	}
+}
+
+// WithClock calibrates the timestamp of the login with the given clock, measuring
+// the skew of the local clock from the Date header of the connection handshake.
+// The clock can be shared with the rest.Client.
+func WithClock(clock *auth.Clock) ClientOption {
+	return func(config *clientConfig) {
+		config.clock = clock
+	}
+}
+
+// WithConnector sets the connector opening the connection of the client, as a
+// recording or replaying one. Default is the gorilla/websocket default dialer.
+func WithConnector(connector Connector) ClientOption {
+	return func(config *clientConfig) {
+		config.connector = connector
+	}
+}
+
+// WithErrorHandler sets a function called with the errors of the connection of the client, as
+// lost connections, stale connections matching models.ErrStaleConnection and failed reconnections
+func WithErrorHandler(handler func(error)) ClientOption {
+	return func(config *clientConfig) {
+		config.errorHandler = handler
+	}
+}
+
+func newClientConfig(options []ClientOption) *clientConfig {
+	config := &clientConfig{baseURL: defaultBaseURL, errorHandler: func(error) {}}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+func (config *clientConfig) signerOrHMAC(apiSecret string) auth.Signer {
+	if config.signer != nil {
+		return config.signer
+	}
+	return auth.NewHMACSigner(apiSecret)
+}
+
+func (config *clientConfig) connectorOrDefault() Connector {
+	if config.connector != nil {
+		return config.connector
+	}
+	return newDialerConnector(config.dialer, config.header)
+}
@@ -0,0 +1,54 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestEndpointOptions(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		requests <- r
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"ch":"trades","update":{"`+strings.Repeat("A", 1024)+`":[]}}`))
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+	client, err := NewMarketDataClient(
+		WithBaseURL("ws"+strings.TrimPrefix(server.URL, "http")+"/"),
+		WithDialer(&websocket.Dialer{HandshakeTimeout: time.Second}),
+		WithHeaders(http.Header{"Proxy-Authorization": {"Basic dXNlcjpwYXNz"}}),
+		WithReadLimit(512),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	request := <-requests
+	if request.URL.Path != "/api/3/ws/public" {
+		t.Fatalf("unexpected path %s", request.URL.Path)
+	}
+	if request.Header.Get("Proxy-Authorization") != "Basic dXNlcjpwYXNz" {
+		t.Fatalf("missing header, got %v", request.Header)
+	}
+	// the message over the read limit closes the connection
+	for event := range client.Events() {
+		if event.Type == EventDisconnected {
+			if !strings.Contains(event.Err.Error(), "read limit") {
+				t.Fatalf("expected the read limit as cause, got %v", event.Err)
+			}
+			return
+		}
+	}
+	t.Fatal("expected a disconnection")
+}
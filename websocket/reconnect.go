@@ -0,0 +1,115 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"time"
+
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
+)
+
+// ReconnectPolicy defines how a client dials again after losing its connection.
+//
+// On reconnection authenticated clients log in again and every active subscription
+// is requested again. Each subscription first receives a notification of type
+// args.NotificationResynced, after which the next notification is a fresh snapshot.
+// Requests in flight when the connection is lost fail with a models.TransportError
+type ReconnectPolicy struct {
+	// MaxAttempts is the number of attempts in a row before giving up and closing the client. 0 or less never gives up
+	MaxAttempts int
+	// BaseDelay is the delay before the first attempt, doubled on every following attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts
+	MaxDelay time.Duration
+}
+
+// DefaultReconnectPolicy returns a policy that never gives up, with delays starting at 500 milliseconds
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		MaxAttempts: 0,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// WithReconnectPolicy reconnects the client with the policy when its connection is lost.
+// By default a client is closed when its connection is lost
+func WithReconnectPolicy(policy ReconnectPolicy) ClientOption {
+	return func(config *clientConfig) {
+		config.reconnectPolicy = &policy
+	}
+}
+
+// delay returns the delay before an attempt, with jitter in the upper half of the exponential backoff
+func (policy ReconnectPolicy) delay(attempt int) time.Duration {
+	backoff := policy.BaseDelay << (attempt - 1)
+	if backoff <= 0 || (policy.MaxDelay > 0 && backoff > policy.MaxDelay) {
+		backoff = policy.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+// resyncedFrame is sent to the subscription channels before their subscriptions are requested again
+var resyncedFrame = []byte(`{"resynced":true}`)
+
+func isResyncedFrame(data []byte) bool {
+	return bytes.Equal(data, resyncedFrame)
+}
+
+// subscriptionRequest is a request of an active subscription, to request it again on reconnection
+type subscriptionRequest struct {
+	method  string
+	channel string
+	params  map[string]interface{}
+}
+
+func (request subscriptionRequest) withID(id int64) interface{} {
+	if request.channel != "" {
+		return wsSubscription{ID: id, Method: request.method, Channel: request.channel, Params: request.params}
+	}
+	return wsNotification{ID: id, Method: request.method, Params: request.params}
+}
+
+// resync logs in again and requests again the active subscriptions over a new connection.
+// Subscriptions rejected by the exchange are closed
+func (client *clientBase) resync() error {
+	if client.signer != nil {
+		if err := client.authenticate(context.Background()); err != nil {
+			return err
+		}
+	}
+	for key, requests := range client.chanCache.subscriptionRequests() {
+		client.chanCache.sendViaSubscriptionCh(key, resyncedFrame)
+		for _, request := range requests {
+			ch := make(chan []byte, 1)
+			id := client.chanCache.saveCh(ch, 1)
+			data, err := json.Marshal(request.withID(id))
+			if err != nil {
+				client.chanCache.closeAndRemoveCh(id)
+				return &models.SDKError{Message: "invalid notification", Err: err}
+			}
+			if err := client.sendRequest(context.Background(), id, data); err != nil {
+				return err
+			}
+			data, err = client.waitResponse(context.Background(), id, ch)
+			if err != nil {
+				return err
+			}
+			var resp struct {
+				Error *models.APIError
+			}
+			json.Unmarshal(data, &resp)
+			if resp.Error != nil {
+				client.chanCache.deleteSubscriptionCh(key)
+				break
+			}
+		}
+	}
+	return nil
+}
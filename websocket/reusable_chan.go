@@ -1,9 +1,14 @@
 package websocket
 
+import "sync"
+
+// reusableChan recieves the responses of a request. Its channel must buffer as many
+// responses as its call count, so a send never blocks and may run under its lock
 type reusableChan struct {
 	channel   chan<- []byte
 	callCount int
 	closed    bool
+	lock      *sync.Mutex
 }
 
 func newReusableChan(channel chan<- []byte, callCount int) *reusableChan {
@@ -11,15 +16,20 @@ func newReusableChan(channel chan<- []byte, callCount int) *reusableChan {
 		channel:   channel,
 		callCount: callCount,
 		closed:    false,
+		lock:      new(sync.Mutex),
 	}
 }
 
 func (reusableChan *reusableChan) isDone() bool {
+	reusableChan.lock.Lock()
+	defer reusableChan.lock.Unlock()
 	return reusableChan.callCount < 1
 }
 
 func (reusableChan *reusableChan) send(data []byte) {
-	if reusableChan.isDone() {
+	reusableChan.lock.Lock()
+	defer reusableChan.lock.Unlock()
+	if reusableChan.closed || reusableChan.callCount < 1 {
 		return
 	}
 	reusableChan.callCount--
@@ -27,6 +37,8 @@ func (reusableChan *reusableChan) send(data []byte) {
 }
 
 func (reusableChan *reusableChan) close() {
+	reusableChan.lock.Lock()
+	defer reusableChan.lock.Unlock()
 	if reusableChan.closed {
 		return
 	}
@@ -13,6 +13,7 @@ import (
 // SpotTradingClient connects via websocket to cryptomarket to enable the user to manage orders. uses SHA256 as auth method and authenticates automatically.
 type SpotTradingClient struct {
 	clientBase
+	validator OrderValidator
 }
 
 // NewSpotTradingClient returns a new spot trading client if the connection with the
@@ -21,26 +22,49 @@ type SpotTradingClient struct {
 // Arguments:
 //
 //	apiKey // The API key
-//	apiSecret // The API secret
+//	apiSecret // The API secret. Ignored if a signer is given with the WithSigner option
 //	window // Maximum difference between the creation of the request and the moment of request processing in milliseconds. Max is 60_000. Defaul is 10_000 (use 0 as argument for default)
-func NewSpotTradingClient(apiKey, apiSecret string, window int) (*SpotTradingClient, error) {
+//	options // Optional. Client options, as WithRateLimiter or WithSigner
+func NewSpotTradingClient(apiKey, apiSecret string, window int, options ...ClientOption) (*SpotTradingClient, error) {
+	return NewSpotTradingClientWithOptions(append([]ClientOption{WithCredentials(apiKey, apiSecret), WithWindow(window)}, options...)...)
+}
+
+// NewSpotTradingClientWithOptions returns a new spot trading client configured by the given options, logged in with
+// the credentials of the WithCredentials option or with the signer of the WithSigner option
+//
+//	client, err := websocket.NewSpotTradingClientWithOptions(
+//		websocket.WithCredentials(apiKey, apiSecret),
+//		websocket.WithBaseURL("ws://localhost:8080"),
+//		websocket.WithDialer(&gorilla.Dialer{Proxy: http.ProxyFromEnvironment, HandshakeTimeout: 10 * time.Second}),
+//	)
+func NewSpotTradingClientWithOptions(options ...ClientOption) (*SpotTradingClient, error) {
+	return NewSpotTradingClientContext(context.Background(), options...)
+}
 
+// NewSpotTradingClientContext is NewSpotTradingClientWithOptions with a context, which cancels the connection to the exchange and the login
+func NewSpotTradingClientContext(ctx context.Context, options ...ClientOption) (*SpotTradingClient, error) {
+	config := newClientConfig(options)
 	client := &SpotTradingClient{
 		clientBase: clientBase{
-			wsManager: newWSManager("/api/3/ws/trading"),
-			chanCache: newChanCache(),
-			window:    window,
+			wsManager:           newWSManager("/api/3/ws/trading", config),
+			chanCache:           newChanCache(),
+			window:              config.window,
+			rateLimiter:         config.rateLimiter,
+			clock:               config.clock,
+			subscriptionTimeout: config.subscriptionTimeout,
+			apiKey:              config.apiKey,
+			signer:              config.signerOrHMAC(config.apiSecret),
 		},
+		validator: config.orderValidator,
 	}
 
-	// connect to streaming
-	if err := client.wsManager.connect(); err != nil {
-		return nil, fmt.Errorf("error in websocket client connection: %s", err)
+	// connect to streaming and handle incomming data
+	if err := client.connect(ctx); err != nil {
+		return nil, fmt.Errorf("error in websocket client connection: %w", err)
 	}
-	// handle incomming data
-	go client.handle(client.wsManager.rcv)
 
-	if err := client.authenticate(apiKey, apiSecret); err != nil {
+	if err := client.authenticate(ctx); err != nil {
+		client.Close()
 		return nil, err
 	}
 	return client, nil
@@ -146,6 +170,14 @@ func (client *SpotTradingClient) CreateSpotOrder(
 	ctx context.Context,
 	arguments ...args.Argument,
 ) (*models.Report, error) {
+	requiredArguments := []string{
+		internal.ArgNameSymbol,
+		internal.ArgNameSide,
+		internal.ArgNameQuantity,
+	}
+	if err := client.validateOrders(arguments, requiredArguments); err != nil {
+		return nil, err
+	}
 	var resp struct {
 		Result models.Report
 	}
@@ -153,11 +185,7 @@ func (client *SpotTradingClient) CreateSpotOrder(
 		ctx,
 		methodCreateSpotOrder,
 		arguments,
-		[]string{
-			internal.ArgNameSymbol,
-			internal.ArgNameSide,
-			internal.ArgNameQuantity,
-		},
+		requiredArguments,
 		&resp,
 	)
 	if err != nil {
@@ -209,12 +237,16 @@ func (client *SpotTradingClient) CreateSpotOrderList(
 	ctx context.Context,
 	arguments ...args.Argument,
 ) ([]models.Report, error) {
+	requiredArguments := []string{internal.ArgNameOrderListID, internal.ArgNameContingencyType, internal.ArgNameOrders}
+	if err := client.validateOrders(arguments, requiredArguments); err != nil {
+		return nil, err
+	}
 	reports := make([]models.Report, 0)
 	err := client.doRequestOfNNotifications(
 		ctx,
 		methodCreateSpotOrderList,
 		arguments,
-		[]string{internal.ArgNameOrderListID, internal.ArgNameContingencyType, internal.ArgNameOrders},
+		requiredArguments,
 		func(data []byte) {
 			var response struct {
 				Result models.Report
@@ -370,7 +402,12 @@ func (client *SpotTradingClient) GetSpotTradingFee(
 //
 // https://api.exchange.cryptomkt.com/#socket-spot-trading
 func (client *SpotTradingClient) SubscribeToReports() (notificationCh chan models.Notification[[]models.Report], err error) {
-	dataCh, err := client.doSubscription(methodSubscribeSpotReports, nil, nil)
+	return client.SubscribeToReportsContext(context.Background())
+}
+
+// SubscribeToReportsContext is SubscribeToReports with a context, which cancels the wait of the response of the exchange
+func (client *SpotTradingClient) SubscribeToReportsContext(ctx context.Context) (notificationCh chan models.Notification[[]models.Report], err error) {
+	dataCh, err := client.doSubscription(ctx, methodSubscribeSpotReports, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -404,6 +441,10 @@ func (client *SpotTradingClient) SubscribeToReports() (notificationCh chan model
 			Method string
 		}
 		for data := range dataCh {
+			if isResyncedFrame(data) {
+				notificationCh <- models.Notification[[]models.Report]{NotificationType: args.NotificationResynced}
+				continue
+			}
 			json.Unmarshal(data, &method)
 			sendMap[method.Method](data)
 		}
@@ -415,7 +456,12 @@ func (client *SpotTradingClient) SubscribeToReports() (notificationCh chan model
 //
 // https://api.exchange.cryptomkt.com/#socket-spot-trading
 func (client *SpotTradingClient) UnsubscribeToReports() (err error) {
-	return client.doUnsubscription(methodSpotUnsubscribe, nil, nil)
+	return client.UnsubscribeToReportsContext(context.Background())
+}
+
+// UnsubscribeToReportsContext is UnsubscribeToReports with a context, which cancels the wait of the response of the exchange
+func (client *SpotTradingClient) UnsubscribeToReportsContext(ctx context.Context) (err error) {
+	return client.doUnsubscription(ctx, methodSpotUnsubscribe, nil, nil)
 }
 
 // Subscribes to the user's balances.
@@ -428,7 +474,12 @@ func (client *SpotTradingClient) UnsubscribeToReports() (err error) {
 //
 //	Mode(string)  // The symbol of the commission rate
 func (client *SpotTradingClient) SubscribeToSpotBalance(arguments ...args.Argument) (notificationCh chan models.Notification[[]models.Balance], err error) {
-	dataCh, err := client.doSubscription(methodSubscribeSpotBalance, arguments, []string{internal.ArgNameMode})
+	return client.SubscribeToSpotBalanceContext(context.Background(), arguments...)
+}
+
+// SubscribeToSpotBalanceContext is SubscribeToSpotBalance with a context, which cancels the wait of the response of the exchange
+func (client *SpotTradingClient) SubscribeToSpotBalanceContext(ctx context.Context, arguments ...args.Argument) (notificationCh chan models.Notification[[]models.Balance], err error) {
+	dataCh, err := client.doSubscription(ctx, methodSubscribeSpotBalance, arguments, []string{internal.ArgNameMode})
 	if err != nil {
 		return nil, err
 	}
@@ -439,6 +490,10 @@ func (client *SpotTradingClient) SubscribeToSpotBalance(arguments ...args.Argume
 			Params []models.Balance
 		}
 		for data := range dataCh {
+			if isResyncedFrame(data) {
+				notificationCh <- models.Notification[[]models.Balance]{NotificationType: args.NotificationResynced}
+				continue
+			}
 			json.Unmarshal(data, &snapshot)
 			notificationCh <- models.Notification[[]models.Balance]{
 				Data:             snapshot.Params,
@@ -455,5 +510,10 @@ func (client *SpotTradingClient) SubscribeToSpotBalance(arguments ...args.Argume
 //
 // https://api.exchange.cryptomkt.com/#subscribe-to-spot-balances
 func (client *SpotTradingClient) UnsubscribeToSpotBalance() (err error) {
-	return client.doUnsubscription(methodUnsubstribeSpotBalance, nil, nil)
+	return client.UnsubscribeToSpotBalanceContext(context.Background())
+}
+
+// UnsubscribeToSpotBalanceContext is UnsubscribeToSpotBalance with a context, which cancels the wait of the response of the exchange
+func (client *SpotTradingClient) UnsubscribeToSpotBalanceContext(ctx context.Context) (err error) {
+	return client.doUnsubscription(ctx, methodUnsubstribeSpotBalance, nil, nil)
 }
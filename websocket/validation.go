@@ -0,0 +1,41 @@
+package websocket
+
+import (
+	"github.com/cryptomkt/cryptomkt-go/v3/args"
+	"github.com/cryptomkt/cryptomkt-go/v3/internal"
+)
+
+// OrderValidator checks a new order before it is sent to the exchange, as a market.Validator
+type OrderValidator interface {
+	ValidateOrder(order args.OrderRequest) error
+}
+
+// WithOrderValidator checks the orders of CreateSpotOrder and CreateSpotOrderList of the
+// SpotTradingClient with the validator, failing with its error before any request to the exchange.
+// Default is no validation
+func WithOrderValidator(validator OrderValidator) ClientOption {
+	return func(config *clientConfig) {
+		config.orderValidator = validator
+	}
+}
+
+// validateOrders validates the order of the arguments, or each order of an order list
+func (client *SpotTradingClient) validateOrders(arguments []args.Argument, requiredArguments []string) error {
+	if client.validator == nil {
+		return nil
+	}
+	params, err := args.BuildParams(arguments, requiredArguments...)
+	if err != nil {
+		return err
+	}
+	orders, isList := params[internal.ArgNameOrders].([]args.OrderRequest)
+	if !isList {
+		orders = []args.OrderRequest{args.OrderRequestOf(params)}
+	}
+	for _, order := range orders {
+		if err := client.validator.ValidateOrder(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
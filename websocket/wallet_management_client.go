@@ -22,25 +22,48 @@ type WalletManagementClient struct {
 // Arguments:
 //
 //	apiKey // The API key
-//	apiSecret // The API secret
+//	apiSecret // The API secret. Ignored if a signer is given with the WithSigner option
 //	window // Maximum difference between the creation of the request and the moment of request processing in milliseconds. Max is 60_000. Defaul is 10_000 (use 0 as argument for default)
-func NewWalletManagementClient(apiKey, apiSecret string, window int) (*WalletManagementClient, error) {
+//	options // Optional. Client options, as WithRateLimiter or WithSigner
+func NewWalletManagementClient(apiKey, apiSecret string, window int, options ...ClientOption) (*WalletManagementClient, error) {
+	return NewWalletManagementClientWithOptions(append([]ClientOption{WithCredentials(apiKey, apiSecret), WithWindow(window)}, options...)...)
+}
+
+// NewWalletManagementClientWithOptions returns a new wallet client configured by the given options, logged in with
+// the credentials of the WithCredentials option or with the signer of the WithSigner option
+//
+//	client, err := websocket.NewWalletManagementClientWithOptions(
+//		websocket.WithCredentials(apiKey, apiSecret),
+//		websocket.WithBaseURL("ws://localhost:8080"),
+//		websocket.WithDialer(&gorilla.Dialer{Proxy: http.ProxyFromEnvironment, HandshakeTimeout: 10 * time.Second}),
+//	)
+func NewWalletManagementClientWithOptions(options ...ClientOption) (*WalletManagementClient, error) {
+	return NewWalletManagementClientContext(context.Background(), options...)
+}
+
+// NewWalletManagementClientContext is NewWalletManagementClientWithOptions with a context, which cancels the connection to the exchange and the login
+func NewWalletManagementClientContext(ctx context.Context, options ...ClientOption) (*WalletManagementClient, error) {
+	config := newClientConfig(options)
 	client := &WalletManagementClient{
 		clientBase: clientBase{
-			wsManager: newWSManager("/api/3/ws/wallet"),
-			chanCache: newChanCache(),
-			window:    window,
+			wsManager:           newWSManager("/api/3/ws/wallet", config),
+			chanCache:           newChanCache(),
+			window:              config.window,
+			rateLimiter:         config.rateLimiter,
+			clock:               config.clock,
+			subscriptionTimeout: config.subscriptionTimeout,
+			apiKey:              config.apiKey,
+			signer:              config.signerOrHMAC(config.apiSecret),
 		},
 	}
 
-	// connect to streaming
-	if err := client.wsManager.connect(); err != nil {
-		return nil, fmt.Errorf("error in websocket client connection: %s", err)
+	// connect to streaming and handle incomming data
+	if err := client.connect(ctx); err != nil {
+		return nil, fmt.Errorf("error in websocket client connection: %w", err)
 	}
-	// handle incomming data
-	go client.handle(client.wsManager.rcv)
 
-	if err := client.authenticate(apiKey, apiSecret); err != nil {
+	if err := client.authenticate(ctx); err != nil {
+		client.Close()
 		return nil, err
 	}
 	return client, nil
@@ -161,7 +184,12 @@ func (client *WalletManagementClient) GetTransactions(
 //
 // https://api.exchange.cryptomkt.com/#subscribe-to-transactions
 func (client *WalletManagementClient) SubscribeToTransactions() (notificationCh chan models.Notification[models.Transaction], err error) {
-	dataCh, err := client.doSubscription(methodSubscribeTransactions, nil, nil)
+	return client.SubscribeToTransactionsContext(context.Background())
+}
+
+// SubscribeToTransactionsContext is SubscribeToTransactions with a context, which cancels the wait of the response of the exchange
+func (client *WalletManagementClient) SubscribeToTransactionsContext(ctx context.Context) (notificationCh chan models.Notification[models.Transaction], err error) {
+	dataCh, err := client.doSubscription(ctx, methodSubscribeTransactions, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +200,10 @@ func (client *WalletManagementClient) SubscribeToTransactions() (notificationCh
 			Params models.Transaction
 		}
 		for data := range dataCh {
+			if isResyncedFrame(data) {
+				notificationCh <- models.Notification[models.Transaction]{NotificationType: args.NotificationResynced}
+				continue
+			}
 			json.Unmarshal(data, &resp)
 			notificationCh <- models.Notification[models.Transaction]{Data: resp.Params, NotificationType: args.NotificationUpdate}
 		}
@@ -183,7 +215,12 @@ func (client *WalletManagementClient) SubscribeToTransactions() (notificationCh
 //
 // https://api.exchange.cryptomkt.com/#subscribe-to-transactions
 func (client *WalletManagementClient) UnsubscribeToTransactions() error {
-	return client.doUnsubscription(methodUnsubscribeTransactions, nil, nil)
+	return client.UnsubscribeToTransactionsContext(context.Background())
+}
+
+// UnsubscribeToTransactionsContext is UnsubscribeToTransactions with a context, which cancels the wait of the response of the exchange
+func (client *WalletManagementClient) UnsubscribeToTransactionsContext(ctx context.Context) error {
+	return client.doUnsubscription(ctx, methodUnsubscribeTransactions, nil, nil)
 }
 
 // SubscribeToWalletBalances subscribe to a feed of the user's wallet balances
@@ -194,7 +231,12 @@ func (client *WalletManagementClient) UnsubscribeToTransactions() error {
 //
 // https://api.exchange.cryptomkt.com/#subscribe-to-wallet-balance
 func (client *WalletManagementClient) SubscribeToWalletBalances() (notificationCh chan models.Notification[[]models.Balance], err error) {
-	dataCh, err := client.doSubscription(methodSubscribeWalletBalances, nil, nil)
+	return client.SubscribeToWalletBalancesContext(context.Background())
+}
+
+// SubscribeToWalletBalancesContext is SubscribeToWalletBalances with a context, which cancels the wait of the response of the exchange
+func (client *WalletManagementClient) SubscribeToWalletBalancesContext(ctx context.Context) (notificationCh chan models.Notification[[]models.Balance], err error) {
+	dataCh, err := client.doSubscription(ctx, methodSubscribeWalletBalances, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -227,6 +269,10 @@ func (client *WalletManagementClient) SubscribeToWalletBalances() (notificationC
 			Method string
 		}
 		for data := range dataCh {
+			if isResyncedFrame(data) {
+				notificationCh <- models.Notification[[]models.Balance]{NotificationType: args.NotificationResynced}
+				continue
+			}
 			json.Unmarshal(data, &method)
 			sendMap[method.Method](data)
 		}
@@ -238,5 +284,10 @@ func (client *WalletManagementClient) SubscribeToWalletBalances() (notificationC
 //
 // https://api.exchange.cryptomkt.com/#subscribe-to-wallet-balance
 func (client *WalletManagementClient) UnsubscribeToWalletBalances() error {
-	return client.doUnsubscription(methodUnsubscribeWalletBalances, nil, nil)
+	return client.UnsubscribeToWalletBalancesContext(context.Background())
+}
+
+// UnsubscribeToWalletBalancesContext is UnsubscribeToWalletBalances with a context, which cancels the wait of the response of the exchange
+func (client *WalletManagementClient) UnsubscribeToWalletBalancesContext(ctx context.Context) error {
+	return client.doUnsubscription(ctx, methodUnsubscribeWalletBalances, nil, nil)
 }
@@ -1,80 +1,289 @@
 package websocket
 
 import (
-	"flag"
+	"context"
+	"errors"
 	"fmt"
-	"net/url"
+	"net"
+	"net/http"
+	"sync"
+	"time"
 
+	"github.com/cryptomkt/cryptomkt-go/v3/auth"
+	"github.com/cryptomkt/cryptomkt-go/v3/models"
 	"github.com/gorilla/websocket"
 )
 
-const addr string = "api.exchange.cryptomkt.com"
-
 // wsManager deals with the server communication, it sends and recieves data
 // the way to use it is to snd via its send channel and to recieve in a loop
 // via its rcv channel. creation and connection are separated. closable
+//
+// With a reconnect policy, a lost connection is dialed again and rcv is kept open
+// until the manager is closed or the policy gives up. onDisconnect runs before dialing
+// again, as the requests in flight will not be answered
 type wsManager struct {
+	url        string
 	streamPath string
-	conn       *websocket.Conn
+	readLimit  int64
+	conn       Conn
 	snd        chan []byte
 	rcv        chan []byte
 	isOpen     bool
+	closed     chan struct{}
+	clock      *auth.Clock
+	connector  Connector
+	// reconnection
+	reconnectPolicy *ReconnectPolicy
+	reconnectLock   *sync.Mutex
+	attempts        int
+	// onDisconnect fails the requests in flight on a lost connection
+	onDisconnect func()
+	// onReconnect resyncs the client over a new connection, as logging in again
+	onReconnect func() error
+	// heartbeat
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	// connLock guards the current connection, whether it is open, the time it was opened and the cause of its drop
+	connLock    *sync.Mutex
+	connectedAt time.Time
+	dropCause   error
+	onError     func(error)
+	events      *events
 }
 
-func newWSManager(path string) *wsManager {
+func newWSManager(path string, config *clientConfig) *wsManager {
 	return &wsManager{
-		streamPath: path,
-		snd:        make(chan []byte, 1),
-		rcv:        make(chan []byte, 1),
-		isOpen:     false,
+		url:             config.baseURL + path,
+		streamPath:      path,
+		readLimit:       config.readLimit,
+		clock:           config.clock,
+		connector:       config.connectorOrDefault(),
+		snd:             make(chan []byte, 1),
+		rcv:             make(chan []byte, 1),
+		isOpen:          false,
+		closed:          make(chan struct{}),
+		reconnectPolicy: config.reconnectPolicy,
+		reconnectLock:   new(sync.Mutex),
+		onDisconnect:    func() {},
+		onReconnect:     func() error { return nil },
+		pingInterval:    config.pingInterval,
+		pongTimeout:     config.pongTimeoutOrDefault(),
+		connLock:        new(sync.Mutex),
+		onError:         config.errorHandler,
+		events:          newEvents(),
 	}
 }
 
-func (ws *wsManager) connect() error {
-	flag.Parse()
-
-	u := url.URL{Scheme: "wss", Host: addr, Path: ws.streamPath}
-
-	c, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+// connect opens a connection and starts sending and recieving over it. The context only
+// cancels the dial of connectors implementing ContextConnector
+func (ws *wsManager) connect(ctx context.Context) error {
+	sent := time.Now()
+	var c Conn
+	var response *http.Response
+	var err error
+	if connector, ok := ws.connector.(ContextConnector); ok {
+		c, response, err = connector.ConnectContext(ctx, ws.url)
+	} else {
+		c, response, err = ws.connector.Connect(ws.url)
+	}
 	if err != nil {
-		return fmt.Errorf("dial: %v", err)
+		return &models.TransportError{Method: "GET", Path: ws.streamPath, Err: fmt.Errorf("dial: %w", err)}
+	}
+	if response != nil {
+		ws.clock.ObserveHeader(response.Header, sent, time.Now())
+	}
+	if limited, ok := c.(interface{ SetReadLimit(limit int64) }); ok && ws.readLimit > 0 {
+		limited.SetReadLimit(ws.readLimit)
 	}
-	ws.conn = c
-
-	go ws.rcvLoop()
-	go ws.sndLoop()
 
+	ws.connLock.Lock()
+	ws.conn = c
 	ws.isOpen = true
+	ws.connectedAt = time.Now()
+	ws.dropCause = nil
+	ws.connLock.Unlock()
+	ws.events.emit(Event{Type: EventConnected})
+
+	done := make(chan struct{})
+	ws.keepAlive(c, done)
+	go ws.rcvLoop(c, done)
+	go ws.sndLoop(c, done)
 	return nil
 }
 
 func (ws *wsManager) close() {
-	ws.isOpen = false
-	close(ws.snd)
+	ws.setOpen(false)
+	close(ws.closed)
 }
 
-func (ws *wsManager) sndLoop() {
-	for msg := range ws.snd {
-		err := ws.conn.WriteMessage(websocket.TextMessage, msg)
-		if err != nil {
+// connected tells whether there is an open connection
+func (ws *wsManager) connected() bool {
+	ws.connLock.Lock()
+	defer ws.connLock.Unlock()
+	return ws.isOpen
+}
+
+func (ws *wsManager) setOpen(open bool) {
+	ws.connLock.Lock()
+	defer ws.connLock.Unlock()
+	ws.isOpen = open
+}
+
+// send queues the data to send over the connection, failing once the manager is closed
+// or the context is done
+func (ws *wsManager) send(ctx context.Context, data []byte) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ws.closed:
+		return errConnectionClosed()
+	case ws.snd <- data:
+		return nil
+	}
+}
+
+func (ws *wsManager) isClosed() bool {
+	select {
+	case <-ws.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// sndLoop sends the messages of snd over the connection until it is lost
+func (ws *wsManager) sndLoop(conn Conn, done chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-ws.closed:
+			// send close msg to server
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
 			return
+		case msg := <-ws.snd:
+			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				ws.closeConn(conn)
+				return
+			}
 		}
 	}
-	// send close msg to server
-	err := ws.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-	if err != nil {
-		return
-	}
 }
 
-func (ws *wsManager) rcvLoop() {
-	defer close(ws.rcv)
+func (ws *wsManager) rcvLoop(conn Conn, done chan struct{}) {
 	for {
-		_, message, err := ws.conn.ReadMessage()
+		_, message, err := conn.ReadMessage()
 		if err != nil {
-			ws.conn.Close()
+			ws.closeConn(conn)
+			close(done)
+			ws.setOpen(false)
+			if ws.isClosed() {
+				ws.finish(nil)
+				return
+			}
+			cause := ws.causeOf(err)
+			ws.onError(cause)
+			ws.events.emit(disconnectionOf(cause))
+			if ws.reconnectPolicy == nil {
+				ws.finish(cause)
+				return
+			}
+			ws.onDisconnect()
+			if !ws.reconnect() {
+				ws.finish(cause)
+			}
 			return
 		}
+		ws.extendDeadline(conn)
 		ws.rcv <- message
 	}
 }
+
+// reconnect dials again with the backoff of the policy until the client is resynced over
+// a new connection, the manager is closed or the attempts run out. Tells whether it reconnected
+func (ws *wsManager) reconnect() bool {
+	ws.reconnectLock.Lock()
+	defer ws.reconnectLock.Unlock()
+	for {
+		ws.attempts++
+		if ws.reconnectPolicy.MaxAttempts > 0 && ws.attempts > ws.reconnectPolicy.MaxAttempts {
+			return false
+		}
+		select {
+		case <-ws.closed:
+			return false
+		case <-time.After(ws.reconnectPolicy.delay(ws.attempts)):
+		}
+		ws.events.emit(Event{Type: EventReconnecting, Attempt: ws.attempts})
+		if err := ws.connect(context.Background()); err != nil {
+			ws.onError(err)
+			ws.events.emit(Event{Type: EventReconnectFailed, Attempt: ws.attempts, Err: err})
+			continue
+		}
+		if err := ws.onReconnect(); err != nil {
+			ws.onError(err)
+			ws.events.emit(Event{Type: EventReconnectFailed, Attempt: ws.attempts, Err: err})
+			// the read loop of the new connection tries again
+			ws.drop(err)
+			return true
+		}
+		ws.attempts = 0
+		ws.events.emit(Event{Type: EventReconnected})
+		return true
+	}
+}
+
+// finish closes rcv and the events after the last connection, with the cause of its loss
+// unless the manager was closed
+func (ws *wsManager) finish(cause error) {
+	if ws.isClosed() {
+		cause = nil
+	}
+	close(ws.rcv)
+	ws.events.close(cause)
+}
+
+// closeConn closes the connection, guarded as the swaps of the current connection
+func (ws *wsManager) closeConn(conn Conn) {
+	ws.connLock.Lock()
+	defer ws.connLock.Unlock()
+	conn.Close()
+}
+
+// drop closes the current connection as lost by the cause, as a stale connection
+func (ws *wsManager) drop(cause error) {
+	ws.connLock.Lock()
+	defer ws.connLock.Unlock()
+	if !ws.isOpen || ws.conn == nil {
+		return
+	}
+	ws.dropCause = cause
+	ws.conn.Close()
+}
+
+// openSince returns the time the current connection was opened
+func (ws *wsManager) openSince() time.Time {
+	ws.connLock.Lock()
+	defer ws.connLock.Unlock()
+	return ws.connectedAt
+}
+
+// causeOf returns the error of a lost connection, given the read error
+func (ws *wsManager) causeOf(err error) error {
+	ws.connLock.Lock()
+	cause := ws.dropCause
+	ws.dropCause = nil
+	ws.connLock.Unlock()
+	if cause != nil {
+		return cause
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		err = fmt.Errorf("%w: no pong in %v", models.ErrStaleConnection, ws.pongTimeout)
+	}
+	return ws.transportError(err)
+}
+
+func (ws *wsManager) transportError(err error) error {
+	return &models.TransportError{Method: "GET", Path: ws.streamPath, Err: err}
+}